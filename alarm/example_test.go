@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alarm_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/alarm"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func ExampleManager_GetStateInfo() {
+	simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+		m, err := alarm.GetManager(c)
+		if err != nil {
+			return err
+		}
+
+		// vcsim does not trigger alarms, simulate a triggered alarm on a VM
+		vm := simulator.Map.Any("VirtualMachine")
+		a := &simulator.Alarm{}
+		a.Info.Name = "VM CPU Usage"
+		simulator.Map.Put(a)
+		vm.Entity().TriggeredAlarmState = []types.AlarmState{{
+			Key:           a.Self.Value + "." + vm.Reference().Value,
+			Entity:        vm.Reference(),
+			Alarm:         a.Self,
+			OverallStatus: types.ManagedEntityStatusRed,
+		}}
+
+		states, err := m.GetStateInfo(ctx, vm.Reference())
+		if err != nil {
+			return err
+		}
+
+		for _, s := range states {
+			fmt.Printf("%s: %s\n", s.Info.Name, s.OverallStatus)
+
+			err = m.AcknowledgeAlarm(ctx, s.Alarm, s.Entity)
+			if err != nil {
+				return err
+			}
+		}
+
+		states, err = m.GetStateInfo(ctx, vm.Reference())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("acknowledged: %t\n", *states[0].Acknowledged)
+
+		return nil
+	})
+	// Output:
+	// VM CPU Usage: red
+	// acknowledged: true
+}
+
+func ExampleManager_CreateAlarm() {
+	simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+		m, err := alarm.GetManager(c)
+		if err != nil {
+			return err
+		}
+
+		vm := simulator.Map.Any("VirtualMachine")
+
+		spec := alarm.NewAlarmSpec("VM powered off", "Virtual machine is powered off",
+			&types.StateAlarmExpression{
+				Operator:  types.StateAlarmOperatorIsEqual,
+				Type:      "VirtualMachine",
+				StatePath: "runtime.powerState",
+				Red:       string(types.VirtualMachinePowerStatePoweredOff),
+			})
+
+		ref, err := m.CreateAlarm(ctx, vm.Reference(), spec)
+		if err != nil {
+			return err
+		}
+
+		entity := vm.Reference()
+		alarms, err := m.GetAlarmInfo(ctx, &entity)
+		if err != nil {
+			return err
+		}
+
+		for _, info := range alarms {
+			fmt.Printf("%s: %t\n", info.Name, info.Alarm == *ref)
+		}
+
+		// vcsim does not trigger alarms, simulate the alarm being triggered
+		vm.Entity().TriggeredAlarmState = []types.AlarmState{{
+			Key:           ref.Value + "." + vm.Reference().Value,
+			Entity:        vm.Reference(),
+			Alarm:         *ref,
+			OverallStatus: types.ManagedEntityStatusRed,
+		}}
+
+		err = m.ClearTriggeredAlarms(ctx, types.AlarmFilterSpec{
+			Status:     []types.ManagedEntityStatus{types.ManagedEntityStatusRed},
+			TypeEntity: string(types.AlarmFilterSpecAlarmTypeByEntityEntityTypeVm),
+		})
+		if err != nil {
+			return err
+		}
+
+		states, err := m.GetAlarmState(ctx, vm.Reference())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("triggered: %d\n", len(states))
+
+		return nil
+	})
+	// Output:
+	// VM powered off: true
+	// triggered: 0
+}
@@ -0,0 +1,215 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alarm
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+type Manager struct {
+	object.Common
+}
+
+// StateInfo is a triggered AlarmState along with the AlarmInfo of its Alarm,
+// which includes the alarm's name and description.
+type StateInfo struct {
+	types.AlarmState
+
+	Info *types.AlarmInfo
+}
+
+// GetManager wraps NewManager, returning object.ErrNotSupported
+// when the client is not connected to a vCenter instance.
+func GetManager(c *vim25.Client) (*Manager, error) {
+	if c.ServiceContent.AlarmManager == nil {
+		return nil, object.ErrNotSupported
+	}
+	return NewManager(c), nil
+}
+
+func NewManager(c *vim25.Client) *Manager {
+	m := Manager{
+		Common: object.NewCommon(c, *c.ServiceContent.AlarmManager),
+	}
+
+	return &m
+}
+
+// GetAlarmState returns the triggered alarm states of the given entity.
+func (m Manager) GetAlarmState(ctx context.Context, entity types.ManagedObjectReference) ([]types.AlarmState, error) {
+	req := types.GetAlarmState{
+		This:   m.Reference(),
+		Entity: entity,
+	}
+
+	res, err := methods.GetAlarmState(ctx, m.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+// GetStateInfo returns the triggered alarm states of the given entity,
+// with each Alarm reference resolved to its AlarmInfo.
+func (m Manager) GetStateInfo(ctx context.Context, entity types.ManagedObjectReference) ([]StateInfo, error) {
+	states, err := m.GetAlarmState(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(states) == 0 {
+		return nil, nil
+	}
+
+	var alarms []mo.Alarm
+	pc := property.DefaultCollector(m.Client())
+	err = pc.Retrieve(ctx, alarmRefs(states), []string{"info"}, &alarms)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStateInfo(states, alarms), nil
+}
+
+// alarmRefs returns the unique Alarm references of the given states.
+func alarmRefs(states []types.AlarmState) []types.ManagedObjectReference {
+	var refs []types.ManagedObjectReference
+	seen := make(map[types.ManagedObjectReference]bool)
+	for _, s := range states {
+		if !seen[s.Alarm] {
+			seen[s.Alarm] = true
+			refs = append(refs, s.Alarm)
+		}
+	}
+	return refs
+}
+
+// newStateInfo pairs each state with the AlarmInfo of its Alarm, Info is nil if the Alarm is not in alarms.
+func newStateInfo(states []types.AlarmState, alarms []mo.Alarm) []StateInfo {
+	info := make(map[types.ManagedObjectReference]*types.AlarmInfo, len(alarms))
+	for i := range alarms {
+		info[alarms[i].Self] = &alarms[i].Info
+	}
+
+	res := make([]StateInfo, len(states))
+	for i, s := range states {
+		res[i] = StateInfo{AlarmState: s, Info: info[s.Alarm]}
+	}
+
+	return res
+}
+
+// AcknowledgeAlarm acknowledges the triggered alarm on the given entity.
+func (m Manager) AcknowledgeAlarm(ctx context.Context, alarm types.ManagedObjectReference, entity types.ManagedObjectReference) error {
+	req := types.AcknowledgeAlarm{
+		This:   m.Reference(),
+		Alarm:  alarm,
+		Entity: entity,
+	}
+
+	_, err := methods.AcknowledgeAlarm(ctx, m.Client(), &req)
+	return err
+}
+
+// GetAlarm returns the alarms defined on the given entity, or all alarms if entity is nil.
+func (m Manager) GetAlarm(ctx context.Context, entity *types.ManagedObjectReference) ([]types.ManagedObjectReference, error) {
+	req := types.GetAlarm{
+		This:   m.Reference(),
+		Entity: entity,
+	}
+
+	res, err := methods.GetAlarm(ctx, m.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+// GetAlarmInfo returns the AlarmInfo of the alarms defined on the given entity, or of all alarms if entity is nil.
+func (m Manager) GetAlarmInfo(ctx context.Context, entity *types.ManagedObjectReference) ([]types.AlarmInfo, error) {
+	refs, err := m.GetAlarm(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	var alarms []mo.Alarm
+	pc := property.DefaultCollector(m.Client())
+	err = pc.Retrieve(ctx, refs, []string{"info"}, &alarms)
+	if err != nil {
+		return nil, err
+	}
+
+	info := make([]types.AlarmInfo, len(alarms))
+	for i := range alarms {
+		info[i] = alarms[i].Info
+	}
+
+	return info, nil
+}
+
+// NewAlarmSpec returns an enabled AlarmSpec that triggers when any of the given expressions,
+// such as a MetricAlarmExpression, StateAlarmExpression or EventAlarmExpression, is true.
+func NewAlarmSpec(name, description string, expression ...types.BaseAlarmExpression) *types.AlarmSpec {
+	return &types.AlarmSpec{
+		Name:        name,
+		Description: description,
+		Enabled:     true,
+		Expression: &types.OrAlarmExpression{
+			Expression: expression,
+		},
+	}
+}
+
+// CreateAlarm creates an alarm on the given entity, returning the new Alarm reference.
+func (m Manager) CreateAlarm(ctx context.Context, entity types.ManagedObjectReference, spec types.BaseAlarmSpec) (*types.ManagedObjectReference, error) {
+	req := types.CreateAlarm{
+		This:   m.Reference(),
+		Entity: entity,
+		Spec:   spec,
+	}
+
+	res, err := methods.CreateAlarm(ctx, m.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.Returnval, nil
+}
+
+// ClearTriggeredAlarms resets the triggered alarms matching the given filter.
+func (m Manager) ClearTriggeredAlarms(ctx context.Context, filter types.AlarmFilterSpec) error {
+	req := types.ClearTriggeredAlarms{
+		This:   m.Reference(),
+		Filter: filter,
+	}
+
+	_, err := methods.ClearTriggeredAlarms(ctx, m.Client(), &req)
+	return err
+}
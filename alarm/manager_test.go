@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alarm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestStateInfo(t *testing.T) {
+	ref := func(id string) types.ManagedObjectReference {
+		return types.ManagedObjectReference{Type: "Alarm", Value: id}
+	}
+
+	states := []types.AlarmState{
+		{Key: "alarm-1.vm-1", Alarm: ref("alarm-1"), OverallStatus: types.ManagedEntityStatusRed},
+		{Key: "alarm-2.vm-1", Alarm: ref("alarm-2"), OverallStatus: types.ManagedEntityStatusYellow},
+		{Key: "alarm-1.vm-2", Alarm: ref("alarm-1"), OverallStatus: types.ManagedEntityStatusYellow},
+		{Key: "alarm-3.vm-1", Alarm: ref("alarm-3"), OverallStatus: types.ManagedEntityStatusRed},
+	}
+
+	refs := alarmRefs(states)
+	expect := []types.ManagedObjectReference{ref("alarm-1"), ref("alarm-2"), ref("alarm-3")}
+	if !reflect.DeepEqual(refs, expect) {
+		t.Errorf("refs=%v", refs)
+	}
+
+	// alarm-3 was removed before its info was retrieved
+	alarms := []mo.Alarm{
+		{ExtensibleManagedObject: mo.ExtensibleManagedObject{Self: ref("alarm-2")}, Info: types.AlarmInfo{AlarmSpec: types.AlarmSpec{Name: "two"}}},
+		{ExtensibleManagedObject: mo.ExtensibleManagedObject{Self: ref("alarm-1")}, Info: types.AlarmInfo{AlarmSpec: types.AlarmSpec{Name: "one"}}},
+	}
+
+	info := newStateInfo(states, alarms)
+	if len(info) != len(states) {
+		t.Fatalf("%d states", len(info))
+	}
+
+	names := []string{"one", "two", "one", ""}
+	for i, s := range info {
+		if s.AlarmState.Key != states[i].Key {
+			t.Errorf("%d: key=%s", i, s.AlarmState.Key)
+		}
+
+		name := ""
+		if s.Info != nil {
+			name = s.Info.Name
+		}
+		if name != names[i] {
+			t.Errorf("%s: name=%q", s.AlarmState.Key, name)
+		}
+	}
+}
+
+func TestNewAlarmSpec(t *testing.T) {
+	expr := &types.StateAlarmExpression{
+		Operator:  types.StateAlarmOperatorIsEqual,
+		Type:      "VirtualMachine",
+		StatePath: "runtime.powerState",
+		Red:       string(types.VirtualMachinePowerStatePoweredOff),
+	}
+
+	spec := NewAlarmSpec("name", "description", expr)
+
+	if spec.Name != "name" || spec.Description != "description" {
+		t.Errorf("name=%q description=%q", spec.Name, spec.Description)
+	}
+
+	if !spec.Enabled {
+		t.Error("expected alarm to be enabled")
+	}
+
+	or, ok := spec.Expression.(*types.OrAlarmExpression)
+	if !ok {
+		t.Fatalf("expression=%T", spec.Expression)
+	}
+
+	if len(or.Expression) != 1 || or.Expression[0] != expr {
+		t.Errorf("expressions=%v", or.Expression)
+	}
+
+	if spec.Action != nil || spec.Setting != nil {
+		t.Errorf("action=%v setting=%v", spec.Action, spec.Setting)
+	}
+}
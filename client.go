@@ -58,7 +58,11 @@ package govmomi
 
 import (
 	"context"
+	"net/http"
 	"net/url"
+	"reflect"
+	"sync"
+	"time"
 
 	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/session"
@@ -71,6 +75,10 @@ type Client struct {
 	*vim25.Client
 
 	SessionManager *session.Manager
+
+	reconnect sync.Mutex
+	mu        sync.Mutex // guards login
+	login     func(context.Context) error
 }
 
 // NewClient creates a new client from a URL. The client authenticates with the
@@ -99,15 +107,94 @@ func NewClient(ctx context.Context, u *url.URL, insecure bool) (*Client, error)
 }
 
 // Login dispatches to the SessionManager.
+// The credentials are retained so the session can be re-established by Reconnect.
 func (c *Client) Login(ctx context.Context, u *url.Userinfo) error {
-	return c.SessionManager.Login(ctx, u)
+	login := func(ctx context.Context) error {
+		return c.SessionManager.Login(ctx, u)
+	}
+
+	if err := login(ctx); err != nil {
+		return err
+	}
+
+	c.SetLogin(login)
+	return nil
+}
+
+// SetLogin sets the function used by Reconnect to re-establish the session.
+// Login sets this function, SetLogin is needed for sessions created by other means,
+// such as SessionManager.LoginByToken or SessionManager.LoginExtensionByCertificate.
+func (c *Client) SetLogin(login func(context.Context) error) {
+	c.mu.Lock()
+	c.login = login
+	c.mu.Unlock()
 }
 
 // Logout dispatches to the SessionManager.
 func (c *Client) Logout(ctx context.Context) error {
 	// Close any idle connections after logging out.
 	defer c.Client.CloseIdleConnections()
-	return c.SessionManager.Logout(ctx)
+
+	err := c.SessionManager.Logout(ctx)
+	if err == nil {
+		c.SetLogin(nil)
+	}
+	return err
+}
+
+// RefreshServiceContent dispatches to vim25.Client.RefreshServiceContent.
+// If the SessionManager reference has changed, for example after a failover, SessionManager is
+// replaced by a session.Manager for the new reference, as the session of the previous one is no longer valid.
+// As with vim25.Client.RefreshServiceContent, it must be called from a single goroutine.
+func (c *Client) RefreshServiceContent(ctx context.Context) error {
+	ref := c.ServiceContent.SessionManager
+
+	err := c.Client.RefreshServiceContent(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(ref, c.ServiceContent.SessionManager) {
+		m := session.NewManager(c.Client)
+		m.Locale = c.SessionManager.Locale
+		c.SessionManager = m
+	}
+
+	return nil
+}
+
+// Reconnect re-establishes the connection to the server after the underlying transport
+// has been lost, for example when vCenter restarts or the network is partitioned.
+// Idle connections and the session cookie are discarded, the ServiceContent is re-fetched and
+// the session is re-authenticated using the function set by Login or SetLogin.
+// If neither was called, for example when the session was created directly via the SessionManager,
+// the session is not re-authenticated and requests will fail with NotAuthenticated.
+// The Client and its RoundTripper are preserved, so existing wrappers remain valid.
+// Requests in flight, such as a property.Wait long poll, do not block Reconnect.
+// Concurrent calls to Reconnect are serialized, see RefreshServiceContent regarding other use of the Client.
+func (c *Client) Reconnect(ctx context.Context) error {
+	c.reconnect.Lock()
+	defer c.reconnect.Unlock()
+
+	c.Client.CloseIdleConnections()
+
+	// The Jar is safe for concurrent use by requests in flight, expire the session cookie rather than replacing the Jar.
+	c.Client.Jar.SetCookies(c.URL(), []*http.Cookie{{Name: soap.SessionCookieName, MaxAge: -1}})
+
+	err := c.RefreshServiceContent(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	login := c.login
+	c.mu.Unlock()
+
+	if login == nil {
+		return nil
+	}
+
+	return login(ctx)
 }
 
 // PropertyCollector returns the session's default property collector.
@@ -125,12 +212,27 @@ func (c *Client) Retrieve(ctx context.Context, objs []types.ManagedObjectReferen
 	return c.PropertyCollector().Retrieve(ctx, objs, p, dst)
 }
 
+// PropertiesConcurrent dispatches to the RetrieveConcurrent function on the default property collector.
+func (c *Client) PropertiesConcurrent(ctx context.Context, objs []types.ManagedObjectReference, p []string, chunkSize, parallelism int, dst interface{}) error {
+	return c.PropertyCollector().RetrieveConcurrent(ctx, objs, p, chunkSize, parallelism, dst)
+}
+
 // Wait dispatches to property.Wait.
 func (c *Client) Wait(ctx context.Context, obj types.ManagedObjectReference, ps []string, f func([]types.PropertyChange) bool) error {
 	return property.Wait(ctx, c.PropertyCollector(), obj, ps, f)
 }
 
+// WaitForState dispatches to property.WaitForState.
+func (c *Client) WaitForState(ctx context.Context, obj types.ManagedObjectReference, p string, want interface{}, timeout time.Duration) error {
+	return property.WaitForState(ctx, c.PropertyCollector(), obj, p, want, timeout)
+}
+
 // IsVC returns true if we are connected to a vCenter
 func (c *Client) IsVC() bool {
 	return c.Client.IsVC()
 }
+
+// IsESXi returns true if we are connected to a standalone ESXi host
+func (c *Client) IsESXi() bool {
+	return c.Client.IsESXi()
+}
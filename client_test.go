@@ -21,11 +21,17 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/vmware/govmomi/session"
 	"github.com/vmware/govmomi/test"
 	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -137,3 +143,242 @@ func TestPropertiesN(t *testing.T) {
 		t.Fatalf("Expected %d, got %d", len(folderReferences), len(folders))
 	}
 }
+
+// reconnectRoundTripper responds to the methods used by Client.Reconnect
+type reconnectRoundTripper struct {
+	logins int32
+
+	mu             sync.Mutex
+	sessionManager string
+
+	polling chan struct{} // closed when WaitForUpdatesEx is called
+	poll    chan struct{} // WaitForUpdatesEx blocks until poll is closed
+}
+
+func (rt *reconnectRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	switch res := res.(type) {
+	case *methods.RetrieveServiceContentBody:
+		rt.mu.Lock()
+		sm := rt.sessionManager
+		rt.mu.Unlock()
+		if sm == "" {
+			sm = "SessionManager"
+		}
+		res.Res = &types.RetrieveServiceContentResponse{
+			Returnval: types.ServiceContent{
+				SessionManager: &types.ManagedObjectReference{Type: "SessionManager", Value: sm},
+			},
+		}
+	case *methods.LoginBody:
+		atomic.AddInt32(&rt.logins, 1)
+		res.Res = new(types.LoginResponse)
+	case *methods.CurrentTimeBody:
+		res.Res = new(types.CurrentTimeResponse)
+	case *methods.CreatePropertyCollectorBody:
+		res.Res = &types.CreatePropertyCollectorResponse{
+			Returnval: types.ManagedObjectReference{Type: "PropertyCollector", Value: "session[wait]"},
+		}
+	case *methods.CreateFilterBody:
+		res.Res = new(types.CreateFilterResponse)
+	case *methods.DestroyPropertyCollectorBody:
+		res.Res = new(types.DestroyPropertyCollectorResponse)
+	case *methods.WaitForUpdatesExBody:
+		close(rt.polling)
+		<-rt.poll
+		res.Res = &types.WaitForUpdatesExResponse{
+			Returnval: &types.UpdateSet{
+				Version: "1",
+				FilterSet: []types.PropertyFilterUpdate{{
+					ObjectSet: []types.ObjectUpdate{{
+						ChangeSet: []types.PropertyChange{{Name: "name", Val: "vcsim"}},
+					}},
+				}},
+			},
+		}
+	}
+	return nil
+}
+
+func TestReconnect(t *testing.T) {
+	ctx := context.Background()
+
+	u := &url.URL{Scheme: "https", Host: "127.0.0.1", Path: vim25.Path}
+	rt := new(reconnectRoundTripper)
+	sc := soap.NewClient(u, true)
+	vc := &vim25.Client{Client: sc, RoundTripper: rt}
+
+	c := &Client{
+		Client:         vc,
+		SessionManager: session.NewManager(vc),
+	}
+
+	err := c.RefreshServiceContent(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.Login(ctx, url.UserPassword("user", "pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.Jar.SetCookies(u, []*http.Cookie{{Name: soap.SessionCookieName, Value: "stale"}})
+
+	// Requests in flight while reconnecting
+	var wg, reconnects sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					if _, err := methods.GetCurrentTime(ctx, c); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 3; i++ {
+		reconnects.Add(1)
+		go func() {
+			defer reconnects.Done()
+			if err := c.Reconnect(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	reconnects.Wait()
+	close(done)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&rt.logins); n != 4 {
+		t.Errorf("logins=%d", n)
+	}
+
+	if cookies := sc.Jar.Cookies(u); len(cookies) != 0 {
+		t.Errorf("cookies=%v", cookies)
+	}
+
+	err = c.Logout(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reconnect after Logout should not login again
+	err = c.Reconnect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&rt.logins); n != 4 {
+		t.Errorf("logins=%d", n)
+	}
+}
+
+func TestRefreshServiceContent(t *testing.T) {
+	ctx := context.Background()
+
+	rt := new(reconnectRoundTripper)
+	vc, err := vim25.NewClient(ctx, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Client{
+		Client:         vc,
+		SessionManager: session.NewManager(vc),
+	}
+	c.SessionManager.Locale = "de_DE"
+
+	sm := c.SessionManager
+	if err = c.RefreshServiceContent(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if c.SessionManager != sm {
+		t.Error("SessionManager replaced")
+	}
+
+	rt.sessionManager = "SessionManager-2"
+	if err = c.RefreshServiceContent(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if c.SessionManager == sm {
+		t.Error("SessionManager not replaced")
+	}
+	if ref := c.SessionManager.Reference(); ref.Value != rt.sessionManager {
+		t.Errorf("SessionManager=%s", ref)
+	}
+	if c.SessionManager.Locale != sm.Locale {
+		t.Errorf("Locale=%s", c.SessionManager.Locale)
+	}
+}
+
+func TestReconnectWait(t *testing.T) {
+	ctx := context.Background()
+
+	u := &url.URL{Scheme: "https", Host: "127.0.0.1", Path: vim25.Path}
+	rt := &reconnectRoundTripper{
+		polling: make(chan struct{}),
+		poll:    make(chan struct{}),
+	}
+	vc := &vim25.Client{Client: soap.NewClient(u, true), RoundTripper: rt}
+
+	c := &Client{
+		Client:         vc,
+		SessionManager: session.NewManager(vc),
+	}
+
+	err := c.RefreshServiceContent(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.Login(ctx, url.UserPassword("user", "pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := types.ManagedObjectReference{Type: "Folder", Value: "group-d1"}
+	waits := make(chan error)
+	go func() {
+		waits <- c.Wait(ctx, obj, []string{"name"}, func([]types.PropertyChange) bool {
+			return true
+		})
+	}()
+	<-rt.polling
+
+	done := make(chan error)
+	go func() {
+		done <- c.Reconnect(ctx)
+	}()
+
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Reconnect blocked by property.Wait")
+	}
+
+	if _, err = methods.GetCurrentTime(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&rt.logins); n != 2 {
+		t.Errorf("logins=%d", n)
+	}
+
+	close(rt.poll)
+	if err = <-waits; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -19,6 +19,7 @@ package event_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vmware/govmomi/event"
 	"github.com/vmware/govmomi/find"
@@ -54,3 +55,38 @@ func ExampleManager_Events() {
 	// *types.VmStartingEvent
 	// *types.VmPoweredOnEvent
 }
+
+func ExampleManager_QueryAllEvents() {
+	simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+		m := event.NewManager(c)
+
+		vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+		if err != nil {
+			return err
+		}
+
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			return err
+		}
+		if err = task.Wait(ctx); err != nil {
+			return err
+		}
+
+		begin := time.Now().Add(-time.Hour)
+		filter := event.NewFilterSpec(vm.Reference(), types.EventFilterSpecRecursionOptionSelf, begin, time.Time{}, "VmPoweredOffEvent")
+
+		events, err := m.QueryAllEvents(ctx, filter, 2)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			fmt.Printf("%T\n", event)
+		}
+
+		return nil
+	})
+	// Output:
+	// *types.VmPoweredOffEvent
+}
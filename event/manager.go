@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/property"
@@ -111,6 +112,73 @@ func (m Manager) QueryEvents(ctx context.Context, filter types.EventFilterSpec)
 	return res.Returnval, nil
 }
 
+// NewFilterSpec returns an EventFilterSpec for events on the given entity, including its children as specified by recursion.
+// The optional kind arguments filter events by type name, for example "VmPoweredOffEvent".
+// A zero begin or end time leaves that end of the time window open.
+func NewFilterSpec(entity types.ManagedObjectReference, recursion types.EventFilterSpecRecursionOption, begin, end time.Time, kind ...string) types.EventFilterSpec {
+	spec := types.EventFilterSpec{
+		Entity: &types.EventFilterSpecByEntity{
+			Entity:    entity,
+			Recursion: recursion,
+		},
+		EventTypeId: kind,
+	}
+
+	if !begin.IsZero() || !end.IsZero() {
+		spec.Time = new(types.EventFilterSpecByTime)
+		if !begin.IsZero() {
+			spec.Time.BeginTime = types.NewTime(begin)
+		}
+		if !end.IsZero() {
+			spec.Time.EndTime = types.NewTime(end)
+		}
+	}
+
+	return spec
+}
+
+// DefaultPageSize is the number of events read per call by QueryAllEvents when pageSize is not positive.
+const DefaultPageSize = 100
+
+// QueryAllEvents returns all events matching the given filter, oldest first.
+// Unlike QueryEvents, which returns at most the server's single-call limit of events,
+// the events are read in pages of pageSize via an EventHistoryCollector.
+// If pageSize is zero or negative, DefaultPageSize is used.
+func (m Manager) QueryAllEvents(ctx context.Context, filter types.EventFilterSpec, pageSize int32) ([]types.BaseEvent, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	collector, err := m.CreateCollectorForEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = collector.Destroy(context.Background())
+	}()
+
+	err = collector.Rewind(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []types.BaseEvent
+
+	for {
+		page, err := collector.ReadNextEvents(ctx, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page) == 0 {
+			return events, nil
+		}
+
+		events = append(events, page...)
+	}
+}
+
 func (m Manager) RetrieveArgumentDescription(ctx context.Context, eventTypeID string) ([]types.EventArgDesc, error) {
 	req := types.RetrieveArgumentDescription{
 		This:        m.Common.Reference(),
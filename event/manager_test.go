@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestQueryAllEventsPageSize(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		m := event.NewManager(c)
+
+		root := c.ServiceContent.RootFolder
+		filter := event.NewFilterSpec(root, types.EventFilterSpecRecursionOptionAll, time.Time{}, time.Time{})
+
+		all, err := m.QueryAllEvents(ctx, filter, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(all) < 2 {
+			t.Fatalf("expected multiple pages, got %d events", len(all))
+		}
+
+		for _, size := range []int32{0, -1} {
+			events, err := m.QueryAllEvents(ctx, filter, size)
+			if err != nil {
+				t.Fatalf("pageSize=%d: %s", size, err)
+			}
+
+			if len(events) != len(all) {
+				t.Errorf("pageSize=%d: %d events, expected %d", size, len(events), len(all))
+			}
+		}
+	})
+}
@@ -19,14 +19,10 @@ package option
 import (
 	"context"
 	"flag"
-	"fmt"
-	"strconv"
 
 	"github.com/vmware/govmomi/govc/cli"
 	"github.com/vmware/govmomi/govc/flags"
 	"github.com/vmware/govmomi/object"
-	"github.com/vmware/govmomi/vim25/soap"
-	"github.com/vmware/govmomi/vim25/types"
 )
 
 type Set struct {
@@ -63,70 +59,12 @@ Examples:
   govc option.set logger.Vsan verbose`
 }
 
-func isInvalidName(err error) bool {
-	if soap.IsSoapFault(err) {
-		soapFault := soap.ToSoapFault(err)
-		if _, ok := soapFault.VimFault().(types.InvalidName); ok {
-			return ok
-		}
-	}
-
-	return false
-}
-
 func (cmd *Set) Update(ctx context.Context, f *flag.FlagSet, m *object.OptionManager) error {
 	if f.NArg() != 2 {
 		return flag.ErrHelp
 	}
 
-	name := f.Arg(0)
-	val := f.Arg(1)
-
-	opts, err := m.Query(ctx, name)
-	if err != nil {
-		if isInvalidName(err) {
-			// If the option doesn't exist, creating one can only have a string Value.
-			// The Key prefix is limited in this case too, it seems to the config.* namespace.
-			return m.Update(ctx, []types.BaseOptionValue{&types.OptionValue{
-				Key:   name,
-				Value: val,
-			}})
-		}
-		return err
-	}
-
-	if len(opts) != 1 {
-		return flag.ErrHelp
-	}
-
-	var set types.AnyType
-
-	switch x := opts[0].GetOptionValue().Value.(type) {
-	case string:
-		set = val
-	case bool:
-		set, err = strconv.ParseBool(val)
-		if err != nil {
-			return err
-		}
-	case int32:
-		s, err := strconv.ParseInt(val, 10, 32)
-		if err != nil {
-			return err
-		}
-		set = int32(s)
-	case int64:
-		set, err = strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return err
-		}
-	default:
-		return fmt.Errorf("type %T conversion not supported", x)
-	}
-
-	opts[0].GetOptionValue().Value = set
-
-	return m.Update(ctx, opts)
+	return m.Set(ctx, f.Arg(0), f.Arg(1))
 }
 
 func (cmd *Set) Run(ctx context.Context, f *flag.FlagSet) error {
@@ -20,7 +20,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io"
+	"io/ioutil"
 	"net/url"
 	"os"
 
@@ -28,7 +28,6 @@ import (
 	"github.com/vmware/govmomi/govc/flags"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/session"
-	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -101,32 +100,18 @@ func (cmd *console) Run(ctx context.Context, f *flag.FlagSet) error {
 		return fmt.Errorf("vm is not powered on (%s)", state)
 	}
 
-	c := vm.Client()
-
-	u := c.URL()
-
 	if cmd.capture != "" {
-		u.Path = "/screen"
-		query := url.Values{"id": []string{vm.Reference().Value}}
-		u.RawQuery = query.Encode()
-
-		param := soap.DefaultDownload
+		b, err := vm.Screenshot(ctx)
+		if err != nil {
+			return err
+		}
 
 		if cmd.capture == "-" {
-			w, _, derr := c.Download(ctx, u, &param)
-			if derr != nil {
-				return derr
-			}
-
-			_, err = io.Copy(os.Stdout, w)
-			if err != nil {
-				return err
-			}
-
-			return w.Close()
+			_, err = os.Stdout.Write(b)
+			return err
 		}
 
-		return c.DownloadFile(ctx, cmd.capture, u, &param)
+		return ioutil.WriteFile(cmd.capture, b, 0666)
 	}
 
 	if cmd.wss {
@@ -135,50 +120,56 @@ func (cmd *console) Run(ctx context.Context, f *flag.FlagSet) error {
 			return err
 		}
 
-		link := fmt.Sprintf("wss://%s:%d/ticket/%s", ticket.Host, ticket.Port, ticket.Ticket)
+		fmt.Fprintln(cmd.Out, object.NewWebMKSURL(ticket))
+		return nil
+	}
+
+	if !cmd.h5 {
+		link, err := vm.VMRCURL(ctx)
+		if err != nil {
+			return err
+		}
+
 		fmt.Fprintln(cmd.Out, link)
 		return nil
 	}
 
-	m := session.NewManager(c)
-	ticket, err := m.AcquireCloneTicket(ctx)
+	c := vm.Client()
+
+	u := c.URL()
+
+	ticket, err := session.NewManager(c).AcquireCloneTicket(ctx)
 	if err != nil {
 		return err
 	}
 
-	var link string
-
-	if cmd.h5 {
-		m := object.NewOptionManager(c, *c.ServiceContent.Setting)
+	m := object.NewOptionManager(c, *c.ServiceContent.Setting)
 
-		opt, err := m.Query(ctx, "VirtualCenter.FQDN")
-		if err != nil {
-			return err
-		}
+	opt, err := m.Query(ctx, "VirtualCenter.FQDN")
+	if err != nil {
+		return err
+	}
 
-		fqdn := opt[0].GetOptionValue().Value.(string)
+	fqdn := opt[0].GetOptionValue().Value.(string)
 
-		var info object.HostCertificateInfo
-		err = info.FromURL(u, nil)
-		if err != nil {
-			return err
-		}
+	var info object.HostCertificateInfo
+	err = info.FromURL(u, nil)
+	if err != nil {
+		return err
+	}
 
-		u.Path = "/ui/webconsole.html"
+	u.Path = "/ui/webconsole.html"
 
-		u.RawQuery = url.Values{
-			"vmId":          []string{vm.Reference().Value},
-			"vmName":        []string{vm.Name()},
-			"serverGuid":    []string{c.ServiceContent.About.InstanceUuid},
-			"host":          []string{fqdn},
-			"sessionTicket": []string{ticket},
-			"thumbprint":    []string{info.ThumbprintSHA1},
-		}.Encode()
+	u.RawQuery = url.Values{
+		"vmId":          []string{vm.Reference().Value},
+		"vmName":        []string{vm.Name()},
+		"serverGuid":    []string{c.ServiceContent.About.InstanceUuid},
+		"host":          []string{fqdn},
+		"sessionTicket": []string{ticket},
+		"thumbprint":    []string{info.ThumbprintSHA1},
+	}.Encode()
 
-		link = u.String()
-	} else {
-		link = fmt.Sprintf("vmrc://clone:%s@%s/?moid=%s", ticket, u.Hostname(), vm.Reference().Value)
-	}
+	link := u.String()
 
 	fmt.Fprintln(cmd.Out, link)
 
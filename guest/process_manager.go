@@ -18,6 +18,8 @@ package guest
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
@@ -99,3 +101,46 @@ func (m ProcessManager) TerminateProcess(ctx context.Context, auth types.BaseGue
 	_, err := methods.TerminateProcessInGuest(ctx, m.c, &req)
 	return err
 }
+
+// Run starts the program described by spec and polls ListProcesses until the process exits,
+// returning the process exit code.
+// If ctx is canceled before the process exits, the process is terminated.
+func (m ProcessManager) Run(ctx context.Context, auth types.BaseGuestAuthentication, spec types.BaseGuestProgramSpec) (int32, error) {
+	pid, err := m.StartProgram(ctx, auth, spec)
+	if err != nil {
+		return 0, err
+	}
+
+	terminate := func() (int32, error) {
+		// ctx is done, use a background context to terminate the process
+		_ = m.TerminateProcess(context.Background(), auth, pid)
+		return 0, ctx.Err()
+	}
+
+	tick := time.NewTicker(time.Second / 2)
+	defer tick.Stop()
+
+	for {
+		procs, err := m.ListProcesses(ctx, auth, []int64{pid})
+		if err != nil {
+			if ctx.Err() != nil {
+				return terminate()
+			}
+			return 0, err
+		}
+
+		if len(procs) == 0 {
+			return 0, fmt.Errorf("guest process %d not found", pid)
+		}
+
+		if procs[0].EndTime != nil {
+			return procs[0].ExitCode, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return terminate()
+		case <-tick.C:
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guest_test
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/guest"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+var auth = &types.NamePasswordAuthentication{Username: "user", Password: "pass"}
+
+// TestProcessManagerRun requires docker, as vcsim runs guest processes in a container backing the VM.
+func TestProcessManagerRun(t *testing.T) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker not found")
+	}
+
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		finder := find.NewFinder(c)
+		pool, err := finder.ResourcePool(ctx, "DC0_H0/Resources")
+		if err != nil {
+			t.Fatal(err)
+		}
+		folder, err := finder.Folder(ctx, "vm")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spec := types.VirtualMachineConfigSpec{
+			Name: "guest",
+			Files: &types.VirtualMachineFileInfo{
+				VmPathName: "[LocalDS_0] guest",
+			},
+			ExtraConfig: []types.BaseOptionValue{
+				&types.OptionValue{Key: "RUN.container", Value: "nginx"},
+			},
+		}
+
+		task, err := folder.CreateVM(ctx, spec, pool, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := task.WaitForResult(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vm := object.NewVirtualMachine(c, info.Result.(types.ManagedObjectReference))
+
+		task, err = vm.PowerOn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			task, _ := vm.PowerOff(ctx)
+			_ = task.Wait(ctx)
+			task, _ = vm.Destroy(ctx)
+			_ = task.Wait(ctx)
+		}()
+
+		m, err := guest.NewOperationsManager(c, vm.Reference()).ProcessManager(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		code, err := m.Run(ctx, auth, &types.GuestProgramSpec{ProgramPath: "/bin/false"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != 1 {
+			t.Errorf("exit code=%d", code)
+		}
+
+		tctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		_, err = m.Run(tctx, auth, &types.GuestProgramSpec{ProgramPath: "/bin/sleep", Arguments: "60"})
+		if err != context.DeadlineExceeded {
+			t.Fatalf("err=%v", err)
+		}
+
+		procs, err := m.ListProcesses(ctx, auth, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, proc := range procs {
+			if proc.EndTime == nil {
+				t.Errorf("process %d was not terminated", proc.Pid)
+			}
+		}
+	})
+}
+
+// fakeProcess emulates a single guest process, as vcsim requires docker to run guest processes.
+type fakeProcess struct {
+	soap.RoundTripper
+
+	mu         sync.Mutex
+	pid        int64  // process id returned by StartProgramInGuest
+	exit       int    // number of ListProcessesInGuest calls after which the process exits, 0 if never
+	code       int32  // exit code
+	cancel     func() // if set, called by ListProcessesInGuest
+	calls      int    // number of ListProcessesInGuest calls
+	terminated bool   // set by TerminateProcessInGuest
+}
+
+func (p *fakeProcess) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch res := res.(type) {
+	case *methods.StartProgramInGuestBody:
+		res.Res = &types.StartProgramInGuestResponse{Returnval: p.pid}
+	case *methods.ListProcessesInGuestBody:
+		p.calls++
+		if p.cancel != nil {
+			p.cancel()
+			return ctx.Err()
+		}
+		info := types.GuestProcessInfo{Pid: p.pid}
+		if p.terminated || (p.exit != 0 && p.calls >= p.exit) {
+			info.EndTime = types.NewTime(time.Now())
+			info.ExitCode = p.code
+		}
+		res.Res = &types.ListProcessesInGuestResponse{Returnval: []types.GuestProcessInfo{info}}
+	case *methods.TerminateProcessInGuestBody:
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		p.terminated = true
+		res.Res = new(types.TerminateProcessInGuestResponse)
+	default:
+		return p.RoundTripper.RoundTrip(ctx, req, res)
+	}
+
+	return nil
+}
+
+func TestProcessManagerRunCancel(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		vm := simulator.Map.Any("VirtualMachine")
+
+		m, err := guest.NewOperationsManager(c, vm.Reference()).ProcessManager(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rt := c.RoundTripper
+		spec := &types.GuestProgramSpec{ProgramPath: "/bin/sleep", Arguments: "60"}
+
+		// process exits
+		p := &fakeProcess{RoundTripper: rt, pid: 42, exit: 2, code: 3}
+		c.RoundTripper = p
+		code, err := m.Run(ctx, auth, spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != 3 {
+			t.Errorf("exit code=%d", code)
+		}
+		if p.calls != 2 {
+			t.Errorf("%d ListProcessesInGuest calls", p.calls)
+		}
+		if p.terminated {
+			t.Error("exited process was terminated")
+		}
+
+		// ctx canceled between polls
+		p = &fakeProcess{RoundTripper: rt, pid: 42}
+		c.RoundTripper = p
+		tctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		_, err = m.Run(tctx, auth, spec)
+		cancel()
+		if err != context.DeadlineExceeded {
+			t.Errorf("err=%v", err)
+		}
+		if !p.terminated {
+			t.Error("process was not terminated")
+		}
+
+		// ctx canceled during ListProcessesInGuest
+		p = &fakeProcess{RoundTripper: rt, pid: 42}
+		c.RoundTripper = p
+		tctx, cancel = context.WithCancel(ctx)
+		p.cancel = cancel
+		_, err = m.Run(tctx, auth, spec)
+		if err != context.Canceled {
+			t.Errorf("err=%v", err)
+		}
+		if !p.terminated {
+			t.Error("process was not terminated")
+		}
+	})
+}
@@ -101,3 +101,59 @@ func (c ClusterComputeResource) PlaceVm(ctx context.Context, spec types.Placemen
 
 	return &res.Returnval, nil
 }
+
+// ClusterConfig builds a ClusterConfigSpecEx for use with ClusterComputeResource.Reconfigure.
+// Only the settings configured via its methods are included in the spec.
+type ClusterConfig struct {
+	types.ClusterConfigSpecEx
+}
+
+// NewClusterConfig returns an empty ClusterConfig.
+func NewClusterConfig() *ClusterConfig {
+	return new(ClusterConfig)
+}
+
+// DRS enables or disables DRS, with the given default automation level.
+func (s *ClusterConfig) DRS(enabled bool, behavior types.DrsBehavior) *ClusterConfig {
+	s.DrsConfig = &types.ClusterDrsConfigInfo{
+		Enabled:           types.NewBool(enabled),
+		DefaultVmBehavior: behavior,
+	}
+	return s
+}
+
+// HA enables or disables vSphere HA.
+func (s *ClusterConfig) HA(enabled bool) *ClusterConfig {
+	s.das().Enabled = types.NewBool(enabled)
+	return s
+}
+
+// AdmissionControl enables or disables HA admission control, using the given policy if not nil.
+func (s *ClusterConfig) AdmissionControl(enabled bool, policy types.BaseClusterDasAdmissionControlPolicy) *ClusterConfig {
+	das := s.das()
+	das.AdmissionControlEnabled = types.NewBool(enabled)
+	das.AdmissionControlPolicy = policy
+	return s
+}
+
+// HostMonitoring enables or disables HA host monitoring.
+func (s *ClusterConfig) HostMonitoring(enabled bool) *ClusterConfig {
+	state := types.ClusterDasConfigInfoServiceStateDisabled
+	if enabled {
+		state = types.ClusterDasConfigInfoServiceStateEnabled
+	}
+	s.das().HostMonitoring = string(state)
+	return s
+}
+
+func (s *ClusterConfig) das() *types.ClusterDasConfigInfo {
+	if s.DasConfig == nil {
+		s.DasConfig = new(types.ClusterDasConfigInfo)
+	}
+	return s.DasConfig
+}
+
+// Spec returns the ClusterConfigSpecEx built by the ClusterConfig.
+func (s *ClusterConfig) Spec() *types.ClusterConfigSpecEx {
+	return &s.ClusterConfigSpecEx
+}
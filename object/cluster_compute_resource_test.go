@@ -16,5 +16,40 @@ limitations under the License.
 
 package object
 
+import (
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
 // ComputeResource should implement the Reference interface.
 var _ Reference = ClusterComputeResource{}
+
+func TestClusterConfig(t *testing.T) {
+	policy := &types.ClusterFailoverLevelAdmissionControlPolicy{FailoverLevel: 1}
+
+	spec := NewClusterConfig().
+		DRS(true, types.DrsBehaviorFullyAutomated).
+		HA(true).
+		AdmissionControl(true, policy).
+		HostMonitoring(false).
+		Spec()
+
+	drs := spec.DrsConfig
+	if drs == nil || !*drs.Enabled || drs.DefaultVmBehavior != types.DrsBehaviorFullyAutomated {
+		t.Errorf("drs=%#v", drs)
+	}
+
+	das := spec.DasConfig
+	if das == nil || !*das.Enabled || !*das.AdmissionControlEnabled || das.AdmissionControlPolicy != policy {
+		t.Fatalf("das=%#v", das)
+	}
+
+	if das.HostMonitoring != string(types.ClusterDasConfigInfoServiceStateDisabled) {
+		t.Errorf("hostMonitoring=%s", das.HostMonitoring)
+	}
+
+	if spec := NewClusterConfig().Spec(); spec.DrsConfig != nil || spec.DasConfig != nil {
+		t.Errorf("spec=%#v", spec)
+	}
+}
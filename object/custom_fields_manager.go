@@ -144,3 +144,37 @@ func (l CustomFieldDefList) ByKey(key int32) *types.CustomFieldDef {
 	}
 	return nil
 }
+
+// Values returns the custom field values of the given entity, keyed by field name.
+// Values for keys that have no field definition are keyed by the literal int key.
+func (m CustomFieldsManager) Values(ctx context.Context, entity types.ManagedObjectReference) (map[string]string, error) {
+	field, err := m.Field(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var me mo.ManagedEntity
+
+	err = m.Properties(ctx, entity, []string{"customValue"}, &me)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(me.CustomValue))
+
+	for _, v := range me.CustomValue {
+		val, ok := v.(*types.CustomFieldStringValue)
+		if !ok {
+			continue
+		}
+
+		name := strconv.Itoa(int(val.Key))
+		if def := field.ByKey(val.Key); def != nil {
+			name = def.Name
+		}
+
+		values[name] = val.Value
+	}
+
+	return values, nil
+}
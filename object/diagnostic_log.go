@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"time"
 )
 
 // DiagnosticLog wraps DiagnosticManager.BrowseLog
@@ -74,3 +75,27 @@ func (l *DiagnosticLog) Copy(ctx context.Context, w io.Writer) (int, error) {
 
 	return written, nil
 }
+
+// Follow copies the log starting from l.Start to the given io.Writer, as Copy does,
+// then polls for new lines every interval until the context is canceled.
+// Returns the context's error once canceled, or the first error of Copy.
+func (l *DiagnosticLog) Follow(ctx context.Context, w io.Writer, interval time.Duration) error {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		_, err := l.Copy(ctx, w)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick.C:
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// logBuffer is an io.Writer safe for use by Follow and the test concurrently
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// waitFor waits until s is written to b
+func (b *logBuffer) waitFor(t *testing.T, s string) {
+	t.Helper()
+
+	for i := 0; !strings.Contains(b.String(), s); i++ {
+		if i == 500 {
+			t.Fatalf("%q not found in log", s)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDiagnosticLogFollow(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		m := object.NewDiagnosticManager(c)
+		em := event.NewManager(c)
+
+		post := func(msg string) {
+			e := &types.GeneralUserEvent{GeneralEvent: types.GeneralEvent{Message: msg}}
+			e.FullFormattedMessage = msg
+			if err := em.PostEvent(ctx, e); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		post("follow one")
+
+		l := m.Log(ctx, nil, "vpxd:vpxd.log")
+		if err := l.Seek(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf logBuffer
+		fctx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() {
+			done <- l.Follow(fctx, &buf, 10*time.Millisecond)
+		}()
+
+		buf.waitFor(t, "follow one")
+		post("follow two")
+		buf.waitFor(t, "follow two")
+
+		cancel()
+		select {
+		case err := <-done:
+			if err != context.Canceled {
+				t.Errorf("err=%v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Follow did not return after cancel")
+		}
+
+		// Start advances between polls, such that each line is written once
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Errorf("lines=%q", lines)
+		}
+
+		h, err := m.BrowseLog(ctx, nil, l.Key, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.Start != h.LineEnd {
+			t.Errorf("start=%d, end=%d", l.Start, h.LineEnd)
+		}
+
+		// invalid key
+		_, err = m.Log(ctx, nil, "enoent").Copy(ctx, &buf)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
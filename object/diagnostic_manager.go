@@ -21,6 +21,7 @@ import (
 
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -83,6 +84,17 @@ func (m DiagnosticManager) GenerateLogBundles(ctx context.Context, includeDefaul
 	return NewTask(m.c, res.Returnval), nil
 }
 
+// DownloadLogBundle downloads the given bundle, as generated by GenerateLogBundles, to file
+// using the client's session.
+func (m DiagnosticManager) DownloadLogBundle(ctx context.Context, bundle types.DiagnosticManagerBundleInfo, file string, param *soap.Download) error {
+	u, err := m.c.ParseURL(bundle.Url)
+	if err != nil {
+		return err
+	}
+
+	return m.c.DownloadFile(ctx, file, u, param)
+}
+
 func (m DiagnosticManager) QueryDescriptions(ctx context.Context, host *HostSystem) ([]types.DiagnosticManagerLogDescriptor, error) {
 	req := types.QueryDescriptions{
 		This: m.Reference(),
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestDiagnosticManagerDownloadLogBundle(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		ds, err := find.NewFinder(c).Datastore(ctx, "LocalDS_0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content := "vcsim log bundle"
+		err = ds.Upload(ctx, strings.NewReader(content), "vcsim-bundle.tgz", &soap.DefaultUpload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// bundle URLs use '*' in place of the server host
+		u := ds.NewURL("vcsim-bundle.tgz")
+		u.Host = "*"
+		bundle := types.DiagnosticManagerBundleInfo{Url: u.String()}
+
+		dir, err := ioutil.TempDir("", "govmomi-bundle")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		file := filepath.Join(dir, "bundle.tgz")
+		m := object.NewDiagnosticManager(c)
+
+		err = m.DownloadLogBundle(ctx, bundle, file, &soap.DefaultDownload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("content=%q", data)
+		}
+
+		bundle.Url = strings.Replace(bundle.Url, "vcsim-bundle", "enoent", 1)
+		err = m.DownloadLogBundle(ctx, bundle, file, &soap.DefaultDownload)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
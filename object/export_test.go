@@ -0,0 +1,29 @@
+/*
+Copyright (c) 2015 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import "github.com/vmware/govmomi/vim25/types"
+
+// Exported for use by the object_test package, which can import the simulator.
+
+var LookupSnapshot = lookupSnapshot
+
+func NewSnapshotMap(tree []types.VirtualMachineSnapshotTree) map[string][]types.ManagedObjectReference {
+	m := make(snapshotMap)
+	m.add("", tree)
+	return m
+}
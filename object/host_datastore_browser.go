@@ -18,6 +18,7 @@ package object
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
@@ -63,3 +64,32 @@ func (b HostDatastoreBrowser) SearchDatastoreSubFolders(ctx context.Context, dat
 
 	return NewTask(b.c, res.Returnval), nil
 }
+
+// List searches datastorePath using the given spec and waits for the results.
+// If recursive is true, SearchDatastoreSubFolders is used to include the results of all sub folders,
+// otherwise SearchDatastore is used and a single result is returned.
+func (b HostDatastoreBrowser) List(ctx context.Context, datastorePath string, spec *types.HostDatastoreBrowserSearchSpec, recursive bool) ([]types.HostDatastoreBrowserSearchResults, error) {
+	search := b.SearchDatastore
+	if recursive {
+		search = b.SearchDatastoreSubFolders
+	}
+
+	task, err := search(ctx, datastorePath, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := info.Result.(type) {
+	case types.HostDatastoreBrowserSearchResults:
+		return []types.HostDatastoreBrowserSearchResults{r}, nil
+	case types.ArrayOfHostDatastoreBrowserSearchResults:
+		return r.HostDatastoreBrowserSearchResults, nil
+	default:
+		return nil, fmt.Errorf("unexpected search result type: %T", r)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestHostDatastoreBrowserList(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		finder := find.NewFinder(c)
+
+		ds, err := finder.DefaultDatastore(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := ds.Browser(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spec := &types.HostDatastoreBrowserSearchSpec{
+			MatchPattern: []string{"*.vmx"},
+		}
+
+		res, err := b.List(ctx, ds.Path(""), spec, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(res))
+		}
+		if len(res[0].File) != 0 {
+			t.Errorf("expected no .vmx files in the root folder, got %d", len(res[0].File))
+		}
+
+		res, err = b.List(ctx, ds.Path(""), spec, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files := 0
+		for _, r := range res {
+			files += len(r.File)
+		}
+		if files == 0 {
+			t.Error("expected .vmx files in sub folders")
+		}
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/vmware/govmomi/vim25"
@@ -58,6 +59,63 @@ func (s HostFirewallSystem) EnableRuleset(ctx context.Context, id string) error
 	return err
 }
 
+// UpdateRuleset updates the ruleset with the given id.
+func (s HostFirewallSystem) UpdateRuleset(ctx context.Context, id string, spec types.HostFirewallRulesetRulesetSpec) error {
+	req := types.UpdateRuleset{
+		This: s.Reference(),
+		Id:   id,
+		Spec: spec,
+	}
+
+	_, err := methods.UpdateRuleset(ctx, s.c, &req)
+	return err
+}
+
+// SetAllowedHosts limits access to the ruleset with the given id to the given IP addresses and CIDR networks,
+// such as "10.0.0.1" or "192.168.0.0/24". If no hosts are given, access is allowed from all IP addresses.
+func (s HostFirewallSystem) SetAllowedHosts(ctx context.Context, id string, hosts ...string) error {
+	allowed, err := NewRulesetIPList(hosts...)
+	if err != nil {
+		return err
+	}
+
+	return s.UpdateRuleset(ctx, id, types.HostFirewallRulesetRulesetSpec{AllowedHosts: *allowed})
+}
+
+// NewRulesetIPList returns a HostFirewallRulesetIpList for the given IP addresses and CIDR networks.
+// If no hosts are given, the list allows all IP addresses.
+func NewRulesetIPList(hosts ...string) (*types.HostFirewallRulesetIpList, error) {
+	list := &types.HostFirewallRulesetIpList{
+		AllIp: len(hosts) == 0,
+	}
+
+	for _, host := range hosts {
+		if strings.Contains(host, "/") {
+			_, network, err := net.ParseCIDR(host)
+			if err != nil {
+				return nil, err
+			}
+
+			prefix, _ := network.Mask.Size()
+			list.IpNetwork = append(list.IpNetwork, types.HostFirewallRulesetIpNetwork{
+				Network:      network.IP.String(),
+				PrefixLength: int32(prefix),
+			})
+
+			continue
+		}
+
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address: %q", host)
+		}
+
+		list.IpAddress = append(list.IpAddress, ip.String())
+	}
+
+	return list, nil
+}
+
 func (s HostFirewallSystem) Refresh(ctx context.Context) error {
 	req := types.RefreshFirewall{
 		This: s.Reference(),
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestNewRulesetIPList(t *testing.T) {
+	list, err := NewRulesetIPList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !list.AllIp {
+		t.Error("expected AllIp")
+	}
+
+	list, err = NewRulesetIPList("10.0.0.1", "192.168.1.17/24", "fd00::1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := &types.HostFirewallRulesetIpList{
+		IpAddress: []string{"10.0.0.1", "fd00::1"},
+		IpNetwork: []types.HostFirewallRulesetIpNetwork{{Network: "192.168.1.0", PrefixLength: 24}},
+	}
+
+	if !reflect.DeepEqual(list, expect) {
+		t.Errorf("list=%#v", list)
+	}
+
+	for _, host := range []string{"10.0.0.256", "10.0.0.0/33", "esx.example.com"} {
+		if _, err = NewRulesetIPList(host); err == nil {
+			t.Errorf("expected error for %s", host)
+		}
+	}
+}
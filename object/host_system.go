@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
+	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
@@ -158,3 +160,38 @@ func (h HostSystem) ExitMaintenanceMode(ctx context.Context, timeout int32) (*Ta
 
 	return NewTask(h.c, res.Returnval), nil
 }
+
+// Reboot reboots the host. If force is false, the host is only rebooted when in maintenance mode.
+func (h HostSystem) Reboot(ctx context.Context, force bool) (*Task, error) {
+	req := types.RebootHost_Task{
+		This:  h.Reference(),
+		Force: force,
+	}
+
+	res, err := methods.RebootHost_Task(ctx, h.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(h.c, res.Returnval), nil
+}
+
+// InMaintenanceMode returns the value of the host's runtime.inMaintenanceMode property.
+func (h HostSystem) InMaintenanceMode(ctx context.Context) (bool, error) {
+	var o mo.HostSystem
+
+	err := h.Properties(ctx, h.Reference(), []string{"runtime.inMaintenanceMode"}, &o)
+	if err != nil {
+		return false, err
+	}
+
+	return o.Runtime.InMaintenanceMode, nil
+}
+
+// WaitForMaintenanceMode waits for the host's runtime.inMaintenanceMode property to equal the given state.
+// If the state is not reached within timeout, a property.TimeoutError is returned.
+// This can be used after waiting for the EnterMaintenanceMode or ExitMaintenanceMode task.
+func (h HostSystem) WaitForMaintenanceMode(ctx context.Context, state bool, timeout time.Duration) error {
+	pc := property.DefaultCollector(h.c)
+	return property.WaitForState(ctx, pc, h.Reference(), "runtime.inMaintenanceMode", state, timeout)
+}
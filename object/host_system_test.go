@@ -17,8 +17,11 @@ import (
 	"context"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/simulator"
 	"github.com/vmware/govmomi/vim25"
 )
@@ -51,3 +54,44 @@ func TestHostSystemManagementIPs(t *testing.T) {
 		return nil
 	})
 }
+
+func TestHostSystemMaintenanceMode(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		host, err := find.NewFinder(c).HostSystem(ctx, "DC0_H0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, enter := range []bool{true, false} {
+			var task *object.Task
+			if enter {
+				task, err = host.EnterMaintenanceMode(ctx, 0, false, nil)
+			} else {
+				task, err = host.ExitMaintenanceMode(ctx, 0)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = task.Wait(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = host.WaitForMaintenanceMode(ctx, enter, time.Second); err != nil {
+				t.Fatal(err)
+			}
+
+			mode, err := host.InMaintenanceMode(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mode != enter {
+				t.Errorf("InMaintenanceMode=%t", mode)
+			}
+		}
+
+		err = host.WaitForMaintenanceMode(ctx, true, 100*time.Millisecond)
+		if _, ok := err.(property.TimeoutError); !ok {
+			t.Errorf("err=%#v", err)
+		}
+	})
+}
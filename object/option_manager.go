@@ -18,9 +18,12 @@ package object
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -34,6 +37,8 @@ func NewOptionManager(c *vim25.Client, ref types.ManagedObjectReference) *Option
 	}
 }
 
+// Query returns the options matching name.
+// If name ends with a ".", all options with that prefix are returned, for example "Config.HostAgent.".
 func (m OptionManager) Query(ctx context.Context, name string) ([]types.BaseOptionValue, error) {
 	req := types.QueryOptions{
 		This: m.Reference(),
@@ -57,3 +62,73 @@ func (m OptionManager) Update(ctx context.Context, value []types.BaseOptionValue
 	_, err := methods.UpdateOptions(ctx, m.Client(), &req)
 	return err
 }
+
+func isInvalidName(err error) bool {
+	if soap.IsSoapFault(err) {
+		soapFault := soap.ToSoapFault(err)
+		if _, ok := soapFault.VimFault().(types.InvalidName); ok {
+			return ok
+		}
+	}
+
+	return false
+}
+
+// Set updates the option with the given name, converting value to the type of the option's current value,
+// which may be a string, bool, int32 or int64.
+// If the option does not exist, it is created with a string value.
+func (m OptionManager) Set(ctx context.Context, name string, value string) error {
+	opts, err := m.Query(ctx, name)
+	if err != nil {
+		if isInvalidName(err) {
+			// If the option doesn't exist, creating one can only have a string Value.
+			// The Key prefix is limited in this case too, it seems to the config.* namespace.
+			return m.Update(ctx, []types.BaseOptionValue{&types.OptionValue{
+				Key:   name,
+				Value: value,
+			}})
+		}
+		return err
+	}
+
+	var opt *types.OptionValue
+	for i := range opts {
+		if o := opts[i].GetOptionValue(); o.Key == name {
+			opt = o
+			break
+		}
+	}
+
+	if opt == nil {
+		return fmt.Errorf("option %q not found", name)
+	}
+
+	var set types.AnyType
+
+	switch x := opt.Value.(type) {
+	case string:
+		set = value
+	case bool:
+		set, err = strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+	case int32:
+		s, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		set = int32(s)
+	case int64:
+		set, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("type %T conversion not supported", x)
+	}
+
+	opt.Value = set
+
+	return m.Update(ctx, []types.BaseOptionValue{opt})
+}
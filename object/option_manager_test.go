@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+)
+
+func TestOptionManagerSet(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		m := object.NewOptionManager(c, *c.ServiceContent.Setting)
+
+		tests := []struct {
+			name  string
+			value string
+			want  interface{}
+		}{
+			{"event.maxAge", "10", int32(10)},
+			{"event.maxAgeEnabled", "false", false},
+			{"VirtualCenter.InstanceName", "vcsim", "vcsim"},
+		}
+
+		for _, test := range tests {
+			if err := m.Set(ctx, test.name, test.value); err != nil {
+				t.Fatal(err)
+			}
+
+			opts, err := m.Query(ctx, test.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if val := opts[0].GetOptionValue().Value; val != test.want {
+				t.Errorf("%s=%#v, expected %#v", test.name, val, test.want)
+			}
+		}
+
+		if err := m.Set(ctx, "event.maxAge", "ten"); err == nil {
+			t.Error("expected error")
+		}
+
+		if err := m.Set(ctx, "config.vcsim.new", "true"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
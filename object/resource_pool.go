@@ -128,6 +128,17 @@ func (p ResourcePool) UpdateConfig(ctx context.Context, name string, config *typ
 	return err
 }
 
+// MoveInto moves the given resource pools, virtual machines and vApps into this resource pool.
+func (p ResourcePool) MoveInto(ctx context.Context, list []types.ManagedObjectReference) error {
+	req := types.MoveIntoResourcePool{
+		This: p.Reference(),
+		List: list,
+	}
+
+	_, err := methods.MoveIntoResourcePool(ctx, p.c, &req)
+	return err
+}
+
 func (p ResourcePool) DestroyChildren(ctx context.Context) error {
 	req := types.DestroyChildren{
 		This: p.Reference(),
@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// ScheduledTask wraps a ScheduledTask created via ScheduledTaskManager.Create.
+type ScheduledTask struct {
+	Common
+}
+
+func NewScheduledTask(c *vim25.Client, ref types.ManagedObjectReference) *ScheduledTask {
+	return &ScheduledTask{
+		Common: NewCommon(c, ref),
+	}
+}
+
+// Info returns the ScheduledTaskInfo of the scheduled task.
+func (t ScheduledTask) Info(ctx context.Context) (*types.ScheduledTaskInfo, error) {
+	var task mo.ScheduledTask
+
+	err := t.Properties(ctx, t.Reference(), []string{"info"}, &task)
+	if err != nil {
+		return nil, err
+	}
+
+	return &task.Info, nil
+}
+
+// Remove removes the scheduled task.
+func (t ScheduledTask) Remove(ctx context.Context) error {
+	req := types.RemoveScheduledTask{
+		This: t.Reference(),
+	}
+
+	_, err := methods.RemoveScheduledTask(ctx, t.Client(), &req)
+	return err
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+type ScheduledTaskManager struct {
+	Common
+}
+
+// GetScheduledTaskManager wraps NewScheduledTaskManager, returning ErrNotSupported
+// when the client is not connected to a vCenter instance.
+func GetScheduledTaskManager(c *vim25.Client) (*ScheduledTaskManager, error) {
+	if c.ServiceContent.ScheduledTaskManager == nil {
+		return nil, ErrNotSupported
+	}
+	return NewScheduledTaskManager(c), nil
+}
+
+func NewScheduledTaskManager(c *vim25.Client) *ScheduledTaskManager {
+	m := ScheduledTaskManager{
+		Common: NewCommon(c, *c.ServiceContent.ScheduledTaskManager),
+	}
+
+	return &m
+}
+
+// NewMethodAction returns a MethodAction for use in a ScheduledTaskSpec,
+// invoking the named method (for example "CreateSnapshot_Task") with the given arguments.
+func NewMethodAction(name string, args ...types.AnyType) *types.MethodAction {
+	action := &types.MethodAction{
+		Name: name,
+	}
+
+	for _, arg := range args {
+		action.Argument = append(action.Argument, types.MethodActionArgument{Value: arg})
+	}
+
+	return action
+}
+
+// NewScheduledTaskSpec returns an enabled ScheduledTaskSpec that runs action as specified by scheduler,
+// for example a types.DailyTaskScheduler and an action created by NewMethodAction.
+func NewScheduledTaskSpec(name, description string, scheduler types.BaseTaskScheduler, action types.BaseAction) *types.ScheduledTaskSpec {
+	return &types.ScheduledTaskSpec{
+		Name:        name,
+		Description: description,
+		Enabled:     true,
+		Scheduler:   scheduler,
+		Action:      action,
+	}
+}
+
+// Create creates a scheduled task for the given entity, returning a reference to the new ScheduledTask.
+// For example, a nightly snapshot can be scheduled using a types.DailyTaskScheduler and
+// NewMethodAction("CreateSnapshot_Task", name, description, false, false).
+func (m ScheduledTaskManager) Create(ctx context.Context, entity types.ManagedObjectReference, spec types.BaseScheduledTaskSpec) (*types.ManagedObjectReference, error) {
+	req := types.CreateScheduledTask{
+		This:   m.Reference(),
+		Entity: entity,
+		Spec:   spec,
+	}
+
+	res, err := methods.CreateScheduledTask(ctx, m.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.Returnval, nil
+}
+
+// Retrieve returns references to the scheduled tasks of the given entity,
+// or all scheduled tasks if entity is nil.
+func (m ScheduledTaskManager) Retrieve(ctx context.Context, entity *types.ManagedObjectReference) ([]types.ManagedObjectReference, error) {
+	req := types.RetrieveEntityScheduledTask{
+		This:   m.Reference(),
+		Entity: entity,
+	}
+
+	res, err := methods.RetrieveEntityScheduledTask(ctx, m.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+// List returns the info of the scheduled tasks of the given entity,
+// or all scheduled tasks if entity is nil.
+func (m ScheduledTaskManager) List(ctx context.Context, entity *types.ManagedObjectReference) ([]types.ScheduledTaskInfo, error) {
+	refs, err := m.Retrieve(ctx, entity)
+	if err != nil || len(refs) == 0 {
+		return nil, err
+	}
+
+	var tasks []mo.ScheduledTask
+	pc := property.DefaultCollector(m.Client())
+	err = pc.Retrieve(ctx, refs, []string{"info"}, &tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	info := make([]types.ScheduledTaskInfo, len(tasks))
+	for i := range tasks {
+		info[i] = tasks[i].Info
+	}
+
+	return info, nil
+}
+
+// Remove removes the given scheduled task.
+// RemoveScheduledTask is a method of the ScheduledTask itself, Remove is a shortcut for NewScheduledTask(c, task).Remove.
+func (m ScheduledTaskManager) Remove(ctx context.Context, task types.ManagedObjectReference) error {
+	return NewScheduledTask(m.Client(), task).Remove(ctx)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestNewMethodAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []types.AnyType
+		expect []types.MethodActionArgument
+	}{
+		{"PowerOffVM_Task", nil, nil},
+		{
+			"CreateSnapshot_Task",
+			[]types.AnyType{"nightly", "nightly snapshot", false, false},
+			[]types.MethodActionArgument{{Value: "nightly"}, {Value: "nightly snapshot"}, {Value: false}, {Value: false}},
+		},
+	}
+
+	for _, test := range tests {
+		action := object.NewMethodAction(test.name, test.args...)
+
+		if action.Name != test.name {
+			t.Errorf("name=%s", action.Name)
+		}
+
+		if !reflect.DeepEqual(action.Argument, test.expect) {
+			t.Errorf("%s: %#v != %#v", test.name, action.Argument, test.expect)
+		}
+	}
+}
+
+func TestNewScheduledTaskSpec(t *testing.T) {
+	scheduler := &types.DailyTaskScheduler{
+		HourlyTaskScheduler: types.HourlyTaskScheduler{
+			RecurrentTaskScheduler: types.RecurrentTaskScheduler{Interval: 1},
+			Minute:                 30,
+		},
+		Hour: 2,
+	}
+	action := object.NewMethodAction("CreateSnapshot_Task", "nightly", "", false, false)
+
+	spec := object.NewScheduledTaskSpec("nightly", "nightly snapshot", scheduler, action)
+
+	expect := &types.ScheduledTaskSpec{
+		Name:        "nightly",
+		Description: "nightly snapshot",
+		Enabled:     true,
+		Scheduler:   scheduler,
+		Action:      action,
+	}
+
+	if !reflect.DeepEqual(spec, expect) {
+		t.Errorf("%#v != %#v", spec, expect)
+	}
+}
+
+func TestScheduledTaskManager(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		m, err := object.GetScheduledTaskManager(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vm := simulator.Map.Any("VirtualMachine").Reference()
+		host := simulator.Map.Any("HostSystem").Reference()
+
+		scheduler := &types.DailyTaskScheduler{
+			HourlyTaskScheduler: types.HourlyTaskScheduler{
+				RecurrentTaskScheduler: types.RecurrentTaskScheduler{Interval: 1},
+			},
+			Hour: 2,
+		}
+
+		create := func(name string, entity types.ManagedObjectReference, method string) types.ManagedObjectReference {
+			spec := object.NewScheduledTaskSpec(name, "", scheduler, object.NewMethodAction(method))
+			ref, err := m.Create(ctx, entity, spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return *ref
+		}
+
+		snapshot := create("nightly snapshot", vm, "CreateSnapshot_Task")
+		create("nightly reboot", host, "RebootHost_Task")
+
+		spec := object.NewScheduledTaskSpec("nightly snapshot", "", scheduler, object.NewMethodAction("CreateSnapshot_Task"))
+		_, err = m.Create(ctx, vm, spec)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if _, ok := soap.ToSoapFault(err).VimFault().(types.DuplicateName); !ok {
+			t.Errorf("fault=%#v", soap.ToSoapFault(err).VimFault())
+		}
+
+		tasks, err := m.List(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tasks) != 2 {
+			t.Fatalf("%d tasks", len(tasks))
+		}
+
+		tasks, err = m.List(ctx, &vm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tasks) != 1 {
+			t.Fatalf("%d tasks", len(tasks))
+		}
+		info := tasks[0]
+		if info.Name != "nightly snapshot" || info.Entity != vm || info.ScheduledTask != snapshot {
+			t.Errorf("info=%#v", info)
+		}
+		if action, ok := info.Action.(*types.MethodAction); !ok || action.Name != "CreateSnapshot_Task" {
+			t.Errorf("action=%#v", info.Action)
+		}
+
+		err = m.Remove(ctx, snapshot)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refs, err := m.Retrieve(ctx, &vm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(refs) != 0 {
+			t.Errorf("refs=%v", refs)
+		}
+
+		refs, err = m.Retrieve(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(refs) != 1 {
+			t.Errorf("refs=%v", refs)
+		}
+	})
+}
@@ -145,6 +145,29 @@ func (m VirtualDiskManager) DeleteVirtualDisk(ctx context.Context, name string,
 	return NewTask(m.c, res.Returnval), nil
 }
 
+// ExtendVirtualDisk extends a virtual disk to the given capacity, in KB.
+// If eagerZero is true, the added space is zeroed out.
+func (m VirtualDiskManager) ExtendVirtualDisk(ctx context.Context, name string, dc *Datacenter, capacityKb int64, eagerZero *bool) (*Task, error) {
+	req := types.ExtendVirtualDisk_Task{
+		This:          m.Reference(),
+		Name:          name,
+		NewCapacityKb: capacityKb,
+		EagerZero:     eagerZero,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.ExtendVirtualDisk_Task(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTask(m.c, res.Returnval), nil
+}
+
 // InflateVirtualDisk inflates a virtual disk.
 func (m VirtualDiskManager) InflateVirtualDisk(ctx context.Context, name string, dc *Datacenter) (*Task, error) {
 	req := types.InflateVirtualDisk_Task{
@@ -20,14 +20,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"net/url"
 	"path"
+	"strconv"
 
 	"github.com/vmware/govmomi/nfc"
 	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -209,6 +213,84 @@ func (v VirtualMachine) Clone(ctx context.Context, folder *Folder, name string,
 	return NewTask(v.c, res.Returnval), nil
 }
 
+// CloneOptions configures VirtualMachine.CloneAndWait.
+// The zero value clones into the source VM's folder, using its resource pool, host and datastore.
+type CloneOptions struct {
+	Folder    *Folder       // Destination folder, defaults to the source VM's parent folder
+	Pool      *ResourcePool // Destination resource pool
+	Host      *HostSystem   // Destination host
+	Datastore *Datastore    // Destination datastore
+
+	// Snapshot, if set, is the name or path of a source VM snapshot to create a linked clone from.
+	Snapshot string
+
+	Customization *types.CustomizationSpec
+	PowerOn       bool
+	Template      bool
+}
+
+// CloneAndWait clones the VM as name, as configured by opts, and waits for the clone task to complete.
+func (v VirtualMachine) CloneAndWait(ctx context.Context, name string, opts CloneOptions) (*VirtualMachine, error) {
+	spec := types.VirtualMachineCloneSpec{
+		Customization: opts.Customization,
+		PowerOn:       opts.PowerOn,
+		Template:      opts.Template,
+	}
+
+	if opts.Pool != nil {
+		ref := opts.Pool.Reference()
+		spec.Location.Pool = &ref
+	}
+
+	if opts.Host != nil {
+		ref := opts.Host.Reference()
+		spec.Location.Host = &ref
+	}
+
+	if opts.Datastore != nil {
+		ref := opts.Datastore.Reference()
+		spec.Location.Datastore = &ref
+	}
+
+	if opts.Snapshot != "" {
+		snapshot, err := v.FindSnapshot(ctx, opts.Snapshot)
+		if err != nil {
+			return nil, err
+		}
+
+		spec.Snapshot = snapshot
+		spec.Location.DiskMoveType = string(types.VirtualMachineRelocateDiskMoveOptionsCreateNewChildDiskBacking)
+	}
+
+	folder := opts.Folder
+	if folder == nil {
+		var vm mo.VirtualMachine
+
+		err := v.Properties(ctx, v.Reference(), []string{"parent"}, &vm)
+		if err != nil {
+			return nil, err
+		}
+
+		if vm.Parent == nil {
+			return nil, fmt.Errorf("%s has no parent folder", v.Reference())
+		}
+
+		folder = NewFolder(v.c, *vm.Parent)
+	}
+
+	task, err := v.Clone(ctx, folder, name, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := task.WaitForResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVirtualMachine(v.c, info.Result.(types.ManagedObjectReference)), nil
+}
+
 func (v VirtualMachine) InstantClone(ctx context.Context, config types.VirtualMachineInstantCloneSpec) (*Task, error) {
 	req := types.InstantClone_Task{
 		This: v.Reference(),
@@ -266,6 +348,151 @@ func (v VirtualMachine) Reconfigure(ctx context.Context, config types.VirtualMac
 	return NewTask(v.c, res.Returnval), nil
 }
 
+func isTrue(v *bool) bool {
+	return v != nil && *v
+}
+
+// SetCPU reconfigures the number of virtual CPUs of the VirtualMachine.
+// If the VirtualMachine is powered on, CPU hot add or hot remove must be enabled.
+// The count is validated against the maximum supported by the VirtualMachine's hardware version
+// and guest OS, as reported by its EnvironmentBrowser, when the VirtualMachine has one.
+func (v VirtualMachine) SetCPU(ctx context.Context, count int32) (*Task, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("invalid CPU count: %d", count)
+	}
+
+	var o mo.VirtualMachine
+
+	err := v.Properties(ctx, v.Reference(), []string{"config.hardware.numCPU", "config.cpuHotAddEnabled", "config.cpuHotRemoveEnabled", "config.guestId", "config.version", "runtime.powerState", "environmentBrowser"}, &o)
+	if err != nil {
+		return nil, err
+	}
+
+	max, err := v.maxCPUs(ctx, &o)
+	if err != nil {
+		return nil, err
+	}
+	if max > 0 && count > max {
+		return nil, fmt.Errorf("invalid CPU count: %d exceeds the maximum of %d", count, max)
+	}
+
+	if o.Config != nil && o.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn {
+		current := o.Config.Hardware.NumCPU
+		if count > current && !isTrue(o.Config.CpuHotAddEnabled) {
+			return nil, errors.New("CPU hot add is not enabled")
+		}
+		if count < current && !isTrue(o.Config.CpuHotRemoveEnabled) {
+			return nil, errors.New("CPU hot remove is not enabled")
+		}
+	}
+
+	return v.Reconfigure(ctx, types.VirtualMachineConfigSpec{NumCPUs: count})
+}
+
+// maxCPUs returns the maximum number of virtual CPUs of the VirtualMachine's config option,
+// from the hardware option's numCPU and the guest OS descriptor's supportedMaxCPUs, or 0 if neither is known.
+func (v VirtualMachine) maxCPUs(ctx context.Context, o *mo.VirtualMachine) (int32, error) {
+	if o.Config == nil || o.EnvironmentBrowser.Value == "" {
+		return 0, nil
+	}
+
+	req := types.QueryConfigOptionEx{
+		This: o.EnvironmentBrowser,
+		Spec: &types.EnvironmentBrowserConfigOptionQuerySpec{
+			Key:     o.Config.Version,
+			GuestId: []string{o.Config.GuestId},
+		},
+	}
+
+	res, err := methods.QueryConfigOptionEx(ctx, v.c, &req)
+	if err != nil {
+		return 0, err
+	}
+
+	opt := res.Returnval
+	if opt == nil {
+		return 0, nil
+	}
+
+	var max int32
+	for _, n := range opt.HardwareOptions.NumCPU {
+		if n > max {
+			max = n
+		}
+	}
+
+	for _, guest := range opt.GuestOSDescriptor {
+		if guest.Id == o.Config.GuestId && guest.SupportedMaxCPUs > 0 {
+			if max == 0 || guest.SupportedMaxCPUs < max {
+				max = guest.SupportedMaxCPUs
+			}
+		}
+	}
+
+	return max, nil
+}
+
+// SetMemory reconfigures the memory size of the VirtualMachine, in MB.
+// If the VirtualMachine is powered on, memory hot add must be enabled and the size can only be increased.
+func (v VirtualMachine) SetMemory(ctx context.Context, mb int64) (*Task, error) {
+	if mb < 1 {
+		return nil, fmt.Errorf("invalid memory size: %d", mb)
+	}
+
+	var o mo.VirtualMachine
+
+	err := v.Properties(ctx, v.Reference(), []string{"config.hardware.memoryMB", "config.memoryHotAddEnabled", "runtime.powerState"}, &o)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Config != nil && o.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn {
+		current := int64(o.Config.Hardware.MemoryMB)
+		if mb > current && !isTrue(o.Config.MemoryHotAddEnabled) {
+			return nil, errors.New("memory hot add is not enabled")
+		}
+		if mb < current {
+			return nil, errors.New("memory cannot be reduced while powered on")
+		}
+	}
+
+	return v.Reconfigure(ctx, types.VirtualMachineConfigSpec{MemoryMB: mb})
+}
+
+// AddDisk adds a new disk of the given size to the VirtualMachine, created on the given datastore.
+// The disk is attached to the first SCSI controller with an available unit number.
+func (v VirtualMachine) AddDisk(ctx context.Context, sizeKB int64, datastore types.ManagedObjectReference, thin bool) (*Task, error) {
+	if sizeKB < 1 {
+		return nil, fmt.Errorf("invalid disk size: %d", sizeKB)
+	}
+
+	devices, err := v.Device(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := devices.PickController((*types.VirtualSCSIController)(nil))
+	if c == nil {
+		return nil, errors.New("no SCSI controller with an available unit number")
+	}
+
+	disk := devices.CreateDisk(c, datastore, "")
+	disk.CapacityInKB = sizeKB
+	disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo).ThinProvisioned = types.NewBool(thin)
+
+	spec := types.VirtualMachineConfigSpec{
+		DeviceChange: []types.BaseVirtualDeviceConfigSpec{
+			&types.VirtualDeviceConfigSpec{
+				Operation:     types.VirtualDeviceConfigSpecOperationAdd,
+				FileOperation: types.VirtualDeviceConfigSpecFileOperationCreate,
+				Device:        disk,
+			},
+		},
+	}
+
+	return v.Reconfigure(ctx, spec)
+}
+
 func (v VirtualMachine) RefreshStorageInfo(ctx context.Context) error {
 	req := types.RefreshStorageInfo{
 		This: v.Reference(),
@@ -511,6 +738,44 @@ func (v VirtualMachine) RemoveDevice(ctx context.Context, keepFiles bool, device
 	return v.configureDevice(ctx, types.VirtualDeviceConfigSpecOperationRemove, fop, device...)
 }
 
+// ChangeNetwork changes the backing of the given ethernet card to the given network,
+// which can be a standard or distributed port group, or an opaque network.
+// The device can be specified by its name (for example "ethernet-0") or its label (for example "Network adapter 1").
+func (v VirtualMachine) ChangeNetwork(ctx context.Context, device string, network NetworkReference) error {
+	devices, err := v.Device(ctx)
+	if err != nil {
+		return err
+	}
+
+	d := devices.Find(device)
+	if d == nil {
+		for _, dev := range devices {
+			if info := dev.GetVirtualDevice().DeviceInfo; info != nil && info.GetDescription().Label == device {
+				d = dev
+				break
+			}
+		}
+	}
+
+	if d == nil {
+		return fmt.Errorf("device '%s' not found", device)
+	}
+
+	nic, ok := d.(types.BaseVirtualEthernetCard)
+	if !ok {
+		return fmt.Errorf("device '%s' is not an ethernet card", device)
+	}
+
+	backing, err := network.EthernetCardBackingInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	nic.GetVirtualEthernetCard().Backing = backing
+
+	return v.EditDevice(ctx, d)
+}
+
 // AttachDisk attaches the given disk to the VirtualMachine
 func (v VirtualMachine) AttachDisk(ctx context.Context, id string, datastore *Datastore, controllerKey int32, unitNumber int32) error {
 	req := types.AttachDisk_Task{
@@ -602,6 +867,213 @@ func (v VirtualMachine) AcquireTicket(ctx context.Context, kind string) (*types.
 	return &res.Returnval, nil
 }
 
+// NewWebMKSURL returns the WebSocket URL of the VM console for the given "webmks" ticket, as returned by AcquireTicket.
+// The ticket's SslThumbprint can be used to verify the certificate of the ticket's host.
+func NewWebMKSURL(ticket *types.VirtualMachineTicket) *url.URL {
+	return &url.URL{
+		Scheme: "wss",
+		Host:   net.JoinHostPort(ticket.Host, strconv.Itoa(int(ticket.Port))),
+		Path:   "/ticket/" + ticket.Ticket,
+	}
+}
+
+// WebMKSURL acquires a "webmks" ticket and returns the WebSocket URL of the VM console,
+// along with the SSL thumbprint of the console host.
+func (v VirtualMachine) WebMKSURL(ctx context.Context) (*url.URL, string, error) {
+	ticket, err := v.AcquireTicket(ctx, string(types.VirtualMachineTicketTypeWebmks))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return NewWebMKSURL(ticket), ticket.SslThumbprint, nil
+}
+
+// VMRCURL acquires a clone ticket of the current session and returns a vmrc:// URL
+// that opens the VM console with VMware Remote Console.
+func (v VirtualMachine) VMRCURL(ctx context.Context) (*url.URL, error) {
+	req := types.AcquireCloneTicket{
+		This: *v.c.ServiceContent.SessionManager,
+	}
+
+	res, err := methods.AcquireCloneTicket(ctx, v.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &url.URL{
+		Scheme:   "vmrc",
+		User:     url.UserPassword("clone", res.Returnval),
+		Host:     v.c.URL().Host,
+		Path:     "/",
+		RawQuery: url.Values{"moid": []string{v.Reference().Value}}.Encode(),
+	}, nil
+}
+
+// Screenshot returns the current console screen of the powered on VM as PNG image data,
+// via the /screen endpoint of the host or vCenter the client is connected to.
+func (v VirtualMachine) Screenshot(ctx context.Context) ([]byte, error) {
+	u := v.c.URL()
+	u.Path = "/screen"
+	u.RawQuery = url.Values{"id": []string{v.Reference().Value}}.Encode()
+
+	param := soap.DefaultDownload
+
+	rc, _, err := v.c.Download(ctx, u, &param)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return ioutil.ReadAll(rc)
+}
+
+const (
+	vncOptionPrefix = "RemoteDisplay.vnc."
+
+	// VNCPortMin and VNCPortMax bound the range of ports EnableVNC chooses from.
+	VNCPortMin = 5900
+	VNCPortMax = 5999
+)
+
+// vncPort returns the VNC port configured in the given extraConfig, or 0 if none.
+func vncPort(options []types.BaseOptionValue) int {
+	port := 0
+	for _, o := range options {
+		opt := o.GetOptionValue()
+		if opt.Key == vncOptionPrefix+"port" {
+			port, _ = strconv.Atoi(fmt.Sprint(opt.Value))
+		}
+	}
+	return port
+}
+
+// vncEnabled returns true if VNC is enabled in the given extraConfig.
+func vncEnabled(options []types.BaseOptionValue) bool {
+	enabled := false
+	for _, o := range options {
+		opt := o.GetOptionValue()
+		if opt.Key == vncOptionPrefix+"enabled" {
+			enabled, _ = strconv.ParseBool(fmt.Sprint(opt.Value))
+		}
+	}
+	return enabled
+}
+
+// vncPortsInUse returns the VNC ports configured by other VMs with VNC enabled on the given host.
+func (v VirtualMachine) vncPortsInUse(ctx context.Context, host types.ManagedObjectReference) (map[int]bool, error) {
+	var h mo.HostSystem
+
+	err := v.Properties(ctx, host, []string{"vm"}, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []types.ManagedObjectReference
+	for _, ref := range h.Vm {
+		if ref != v.Reference() {
+			refs = append(refs, ref)
+		}
+	}
+
+	ports := make(map[int]bool)
+	if len(refs) == 0 {
+		return ports, nil
+	}
+
+	var vms []mo.VirtualMachine
+	pc := property.DefaultCollector(v.c)
+	err = pc.Retrieve(ctx, refs, []string{"config.extraConfig"}, &vms)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vm := range vms {
+		if vm.Config == nil || !vncEnabled(vm.Config.ExtraConfig) {
+			continue
+		}
+		if port := vncPort(vm.Config.ExtraConfig); port != 0 {
+			ports[port] = true
+		}
+	}
+
+	return ports, nil
+}
+
+// EnableVNC enables VNC access to the VM console on the given port, protected by password,
+// and returns the port. If port is 0, the VM's current VNC port is reused if it has one,
+// otherwise the lowest port between VNCPortMin and VNCPortMax that is not used by another VM on the same host is chosen.
+// An error is returned if the given port is already used by another VM on the same host.
+func (v VirtualMachine) EnableVNC(ctx context.Context, port int, password string) (int, error) {
+	if port < 0 || port > 65535 {
+		return 0, fmt.Errorf("invalid VNC port: %d", port)
+	}
+
+	var vm mo.VirtualMachine
+
+	err := v.Properties(ctx, v.Reference(), []string{"config.extraConfig", "runtime.host"}, &vm)
+	if err != nil {
+		return 0, err
+	}
+
+	if vm.Runtime.Host == nil {
+		return 0, fmt.Errorf("%s has no host", v.Reference())
+	}
+
+	used, err := v.vncPortsInUse(ctx, *vm.Runtime.Host)
+	if err != nil {
+		return 0, err
+	}
+
+	if port == 0 && vm.Config != nil {
+		if current := vncPort(vm.Config.ExtraConfig); current != 0 && !used[current] {
+			port = current
+		}
+	}
+
+	if port == 0 {
+		for p := VNCPortMin; p <= VNCPortMax; p++ {
+			if !used[p] {
+				port = p
+				break
+			}
+		}
+		if port == 0 {
+			return 0, fmt.Errorf("no unused VNC port in range %d-%d", VNCPortMin, VNCPortMax)
+		}
+	} else if used[port] {
+		return 0, fmt.Errorf("VNC port %d is already in use on host %s", port, vm.Runtime.Host.Value)
+	}
+
+	err = v.reconfigureVNC(ctx, "true", strconv.Itoa(port), password)
+	if err != nil {
+		return 0, err
+	}
+
+	return port, nil
+}
+
+// DisableVNC disables VNC access to the VM console.
+func (v VirtualMachine) DisableVNC(ctx context.Context) error {
+	return v.reconfigureVNC(ctx, "false", "", "")
+}
+
+func (v VirtualMachine) reconfigureVNC(ctx context.Context, enabled, port, password string) error {
+	spec := types.VirtualMachineConfigSpec{
+		ExtraConfig: []types.BaseOptionValue{
+			&types.OptionValue{Key: vncOptionPrefix + "enabled", Value: enabled},
+			&types.OptionValue{Key: vncOptionPrefix + "port", Value: port},
+			&types.OptionValue{Key: vncOptionPrefix + "password", Value: password},
+		},
+	}
+
+	task, err := v.Reconfigure(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(ctx)
+}
+
 // CreateSnapshot creates a new snapshot of a virtual machine.
 func (v VirtualMachine) CreateSnapshot(ctx context.Context, name string, description string, memory bool, quiesce bool) (*Task, error) {
 	req := types.CreateSnapshot_Task{
@@ -649,13 +1121,53 @@ func (m snapshotMap) add(parent string, tree []types.VirtualMachineSnapshotTree)
 		}
 
 		for _, name := range names {
-			m[name] = append(m[name], tree[i].Snapshot)
+			m.append(name, tree[i].Snapshot)
 		}
 
 		m.add(sname, st.ChildSnapshotList)
 	}
 }
 
+func (m snapshotMap) append(name string, ref types.ManagedObjectReference) {
+	for _, s := range m[name] {
+		if s == ref {
+			return
+		}
+	}
+	m[name] = append(m[name], ref)
+}
+
+// findSnapshotID returns the snapshot with the given numeric Id, or nil if not found.
+func findSnapshotID(tree []types.VirtualMachineSnapshotTree, id int32) *types.ManagedObjectReference {
+	for i := range tree {
+		if tree[i].Id == id {
+			return &tree[i].Snapshot
+		}
+		if ref := findSnapshotID(tree[i].ChildSnapshotList, id); ref != nil {
+			return ref
+		}
+	}
+	return nil
+}
+
+// lookupSnapshot resolves name as described by FindSnapshot.
+func lookupSnapshot(tree []types.VirtualMachineSnapshotTree, name string) []types.ManagedObjectReference {
+	m := make(snapshotMap)
+	m.add("", tree)
+
+	s := m[name]
+	if len(s) == 0 {
+		// Fallback to the numeric Id only when no name or path matched
+		if id, err := strconv.ParseInt(name, 10, 32); err == nil {
+			if ref := findSnapshotID(tree, int32(id)); ref != nil {
+				s = append(s, *ref)
+			}
+		}
+	}
+
+	return s
+}
+
 // SnapshotSize calculates the size of a given snapshot in bytes. If the
 // snapshot is current, disk files not associated with any parent snapshot are
 // included in size calculations. This allows for measuring and including the
@@ -717,6 +1229,7 @@ func SnapshotSize(info types.ManagedObjectReference, parent *types.ManagedObject
 // 1) snapshot ManagedObjectReference.Value (unique)
 // 2) snapshot name (may not be unique)
 // 3) snapshot tree path (may not be unique)
+// 4) snapshot numeric Id (unique), only if no snapshot matched 1-3
 func (v VirtualMachine) FindSnapshot(ctx context.Context, name string) (*types.ManagedObjectReference, error) {
 	var o mo.VirtualMachine
 
@@ -729,10 +1242,7 @@ func (v VirtualMachine) FindSnapshot(ctx context.Context, name string) (*types.M
 		return nil, errors.New("no snapshots for this VM")
 	}
 
-	m := make(snapshotMap)
-	m.add("", o.Snapshot.RootSnapshotList)
-
-	s := m[name]
+	s := lookupSnapshot(o.Snapshot.RootSnapshotList, name)
 	switch len(s) {
 	case 0:
 		return nil, fmt.Errorf("snapshot %q not found", name)
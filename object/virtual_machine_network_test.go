@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestVirtualMachineChangeNetwork(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		finder := find.NewFinder(c)
+
+		vm, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pg, err := finder.Network(ctx, "DC0_DVPG0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		backing := func() types.BaseVirtualDeviceBackingInfo {
+			devices, err := vm.Device(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			nics := devices.SelectByType((*types.VirtualEthernetCard)(nil))
+			return nics[0].GetVirtualDevice().Backing
+		}
+
+		net, err := finder.Network(ctx, "VM Network")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = vm.ChangeNetwork(ctx, "ethernet-0", net)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := backing().(*types.VirtualEthernetCardNetworkBackingInfo); !ok {
+			t.Fatalf("unexpected backing: %T", backing())
+		}
+
+		devices, _ := vm.Device(ctx)
+		label := devices.Find("ethernet-0").GetVirtualDevice().DeviceInfo.GetDescription().Label
+
+		err = vm.ChangeNetwork(ctx, label, pg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := backing().(*types.VirtualEthernetCardDistributedVirtualPortBackingInfo); !ok {
+			t.Fatalf("unexpected backing: %T", backing())
+		}
+
+		err = vm.ChangeNetwork(ctx, "ethernet-9", net)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
@@ -14,17 +14,28 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package object
+package object_test
 
 import (
+	"bytes"
+	"context"
+	"image/png"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
 // VirtualMachine should implement the Reference interface.
-var _ Reference = VirtualMachine{}
+var _ object.Reference = object.VirtualMachine{}
 
 // pretty.Printf generated
 var snapshot = &types.VirtualMachineSnapshotInfo{
@@ -181,8 +192,7 @@ var snapshot = &types.VirtualMachineSnapshotInfo{
 }
 
 func TestVirtualMachineSnapshotMap(t *testing.T) {
-	m := make(snapshotMap)
-	m.add("", snapshot.RootSnapshotList)
+	m := object.NewSnapshotMap(snapshot.RootSnapshotList)
 
 	tests := []struct {
 		name   string
@@ -194,6 +204,7 @@ func TestVirtualMachineSnapshotMap(t *testing.T) {
 		{"root/child", 2},
 		{"root/voodoo/child", 1},
 		{"2-snapshot-6", 1},
+		{"6", 0},
 	}
 
 	for _, test := range tests {
@@ -204,3 +215,363 @@ func TestVirtualMachineSnapshotMap(t *testing.T) {
 		}
 	}
 }
+
+func TestVirtualMachineSnapshotLookup(t *testing.T) {
+	ref := func(id string) types.ManagedObjectReference {
+		return types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: id}
+	}
+
+	tree := []types.VirtualMachineSnapshotTree{
+		{Snapshot: ref("2-snapshot-1"), Name: "1", Id: 1},
+		{
+			Snapshot: ref("2-snapshot-2"), Name: "6", Id: 2,
+			ChildSnapshotList: []types.VirtualMachineSnapshotTree{
+				{Snapshot: ref("2-snapshot-6"), Name: "six", Id: 6},
+			},
+		},
+		{Snapshot: ref("2-snapshot-3"), Name: "2-snapshot-3", Id: 3},
+	}
+
+	tests := []struct {
+		name   string
+		expect []types.ManagedObjectReference
+	}{
+		{"enoent", nil},
+		{"1", []types.ManagedObjectReference{ref("2-snapshot-1")}}, // name and Id match the same snapshot
+		{"6", []types.ManagedObjectReference{ref("2-snapshot-2")}}, // name takes precedence over Id
+		{"2", []types.ManagedObjectReference{ref("2-snapshot-2")}}, // Id fallback
+		{"12", nil},
+		{"2-snapshot-6", []types.ManagedObjectReference{ref("2-snapshot-6")}},
+		{"2-snapshot-3", []types.ManagedObjectReference{ref("2-snapshot-3")}}, // name and Value match the same snapshot
+	}
+
+	for _, test := range tests {
+		s := object.LookupSnapshot(tree, test.name)
+
+		if !reflect.DeepEqual(s, test.expect) {
+			t.Errorf("%s: %v != %v", test.name, s, test.expect)
+		}
+	}
+}
+
+func TestVirtualMachineCloneAndWait(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		finder := find.NewFinder(c)
+
+		vm, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clone, err := vm.CloneAndWait(ctx, "clone0", object.CloneOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var src, dst mo.VirtualMachine
+		if err = vm.Properties(ctx, vm.Reference(), []string{"parent"}, &src); err != nil {
+			t.Fatal(err)
+		}
+		if err = clone.Properties(ctx, clone.Reference(), []string{"name", "parent"}, &dst); err != nil {
+			t.Fatal(err)
+		}
+
+		if dst.Name != "clone0" {
+			t.Errorf("name=%s", dst.Name)
+		}
+		if *dst.Parent != *src.Parent {
+			t.Errorf("parent=%s", dst.Parent)
+		}
+
+		task, err := vm.CreateSnapshot(ctx, "root", "", false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = vm.CloneAndWait(ctx, "clone1", object.CloneOptions{Snapshot: "root"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = vm.CloneAndWait(ctx, "clone2", object.CloneOptions{Snapshot: "enoent"})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestNewWebMKSURL(t *testing.T) {
+	ticket := &types.VirtualMachineTicket{
+		Ticket: "52a0b8c1",
+		Host:   "esx.example.com",
+		Port:   443,
+	}
+
+	u := object.NewWebMKSURL(ticket).String()
+	if u != "wss://esx.example.com:443/ticket/52a0b8c1" {
+		t.Errorf("url=%s", u)
+	}
+}
+
+func TestVirtualMachineVMRCURL(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		ref := simulator.Map.Any("VirtualMachine").Reference()
+		vm := object.NewVirtualMachine(c, ref)
+
+		u, err := vm.VMRCURL(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ticket, _ := u.User.Password()
+		if u.Scheme != "vmrc" || u.User.Username() != "clone" || ticket == "" {
+			t.Errorf("url=%s", u)
+		}
+
+		// vcsim listens on a non-default port, which must be included
+		if !strings.HasSuffix(u.String(), "/?moid="+ref.Value) || u.Host != c.URL().Host {
+			t.Errorf("url=%s", u)
+		}
+	})
+}
+
+// screenRecorder records the URL of each http request
+type screenRecorder struct {
+	http.RoundTripper
+	urls []string
+}
+
+func (r *screenRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.urls = append(r.urls, req.URL.String())
+	return r.RoundTripper.RoundTrip(req)
+}
+
+func TestVirtualMachineScreenshot(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		ref := simulator.Map.Any("VirtualMachine").Reference()
+		vm := object.NewVirtualMachine(c, ref)
+
+		sdk := c.URL().String()
+		rec := &screenRecorder{RoundTripper: c.Transport}
+		c.Transport = rec
+
+		data, err := vm.Screenshot(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = png.DecodeConfig(bytes.NewReader(data)); err != nil {
+			t.Error(err)
+		}
+
+		u := *c.URL()
+		u.Path = "/screen"
+		u.RawQuery = "id=" + ref.Value
+		if len(rec.urls) != 1 || rec.urls[0] != u.String() {
+			t.Errorf("urls=%v", rec.urls)
+		}
+
+		if c.URL().String() != sdk {
+			t.Errorf("client url=%s", c.URL())
+		}
+
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = vm.Screenshot(ctx); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestVirtualMachineSetHardware(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		finder := find.NewFinder(c)
+
+		vm, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ds, err := finder.Datastore(ctx, "LocalDS_0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// powered on without hot add enabled
+		if _, err = vm.SetCPU(ctx, 4); err == nil {
+			t.Error("expected error")
+		}
+		if _, err = vm.SetMemory(ctx, 2048); err == nil {
+			t.Error("expected error")
+		}
+
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		task, err = vm.SetCPU(ctx, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		// vcsim VMs have no EnvironmentBrowser by default, use that of the cluster with a limited config option
+		cluster := simulator.Map.Any("ClusterComputeResource").(*simulator.ClusterComputeResource)
+		browser := simulator.Map.Get(*cluster.EnvironmentBrowser).(*simulator.EnvironmentBrowser)
+		browser.QueryConfigOptionResponse.Returnval = &types.VirtualMachineConfigOption{
+			HardwareOptions: types.VirtualHardwareOption{NumCPU: []int32{1, 2, 4, 8}},
+		}
+		simulator.Map.Get(vm.Reference()).(*simulator.VirtualMachine).EnvironmentBrowser = browser.Self
+
+		if _, err = vm.SetCPU(ctx, 16); err == nil {
+			t.Error("expected error")
+		}
+
+		task, err = vm.SetMemory(ctx, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		devices, err := vm.Device(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ndisks := len(devices.SelectByType((*types.VirtualDisk)(nil)))
+
+		task, err = vm.AddDisk(ctx, 1024*1024, ds.Reference(), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		var o mo.VirtualMachine
+		err = vm.Properties(ctx, vm.Reference(), []string{"config.hardware"}, &o)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hw := o.Config.Hardware
+		if hw.NumCPU != 4 || hw.MemoryMB != 2048 {
+			t.Errorf("cpu=%d, mem=%d", hw.NumCPU, hw.MemoryMB)
+		}
+
+		disks := object.VirtualDeviceList(hw.Device).SelectByType((*types.VirtualDisk)(nil))
+		if len(disks) != ndisks+1 {
+			t.Fatalf("disks=%d", len(disks))
+		}
+
+		disk := disks[len(disks)-1].(*types.VirtualDisk)
+		if disk.CapacityInKB != 1024*1024 {
+			t.Errorf("capacity=%d", disk.CapacityInKB)
+		}
+
+		if _, err = vm.SetCPU(ctx, 0); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestVirtualMachineVNC(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		finder := find.NewFinder(c)
+
+		vm0, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vm1, err := finder.VirtualMachine(ctx, "DC0_H0_VM1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		port, err := vm0.EnableVNC(ctx, 0, "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port != object.VNCPortMin {
+			t.Errorf("port=%d", port)
+		}
+
+		// current port is reused
+		port, err = vm0.EnableVNC(ctx, 0, "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port != object.VNCPortMin {
+			t.Errorf("port=%d", port)
+		}
+
+		port, err = vm1.EnableVNC(ctx, 0, "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port != object.VNCPortMin+1 {
+			t.Errorf("port=%d", port)
+		}
+
+		_, err = vm1.EnableVNC(ctx, object.VNCPortMin, "secret")
+		if err == nil {
+			t.Error("expected error")
+		}
+
+		_, err = vm1.EnableVNC(ctx, 70000, "secret")
+		if err == nil {
+			t.Error("expected error")
+		}
+
+		if err = vm0.DisableVNC(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		port, err = vm1.EnableVNC(ctx, object.VNCPortMin, "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port != object.VNCPortMin {
+			t.Errorf("port=%d", port)
+		}
+
+		// the port of a VM with VNC disabled is not in use
+		task, err := vm0.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+			ExtraConfig: []types.BaseOptionValue{
+				&types.OptionValue{Key: "RemoteDisplay.vnc.enabled", Value: "false"},
+				&types.OptionValue{Key: "RemoteDisplay.vnc.port", Value: "5901"},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		port, err = vm1.EnableVNC(ctx, 5901, "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port != 5901 {
+			t.Errorf("port=%d", port)
+		}
+	})
+}
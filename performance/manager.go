@@ -271,6 +271,22 @@ func (m *Manager) Query(ctx context.Context, spec []types.PerfQuerySpec) ([]type
 	return res.Returnval, nil
 }
 
+// QueryComposite wraps the QueryPerfComposite method.
+// The spec entity must be a HostSystem, the result includes metrics for the host and its virtual machines.
+func (m *Manager) QueryComposite(ctx context.Context, spec types.PerfQuerySpec) (*types.PerfCompositeMetric, error) {
+	req := types.QueryPerfComposite{
+		This:      m.Reference(),
+		QuerySpec: spec,
+	}
+
+	res, err := methods.QueryPerfComposite(ctx, m.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.Returnval, nil
+}
+
 // QueryCounter wraps the QueryPerfCounter method.
 func (m *Manager) QueryCounter(ctx context.Context, ids []int32) ([]types.PerfCounterInfo, error) {
 	req := types.QueryPerfCounter{
@@ -0,0 +1,248 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Cache is an opt-in, in-memory cache of object properties retrieved via a Collector.
+// It is intended for slowly-changing properties, such as host hardware or datastore capacity,
+// to avoid repeated RetrieveProperties calls for the same data.
+// A Cache is safe for concurrent use.
+type Cache struct {
+	c *Collector
+
+	mu      sync.Mutex
+	gen     uint64 // incremented by Invalidate
+	entries map[cacheKey]cacheEntry
+	calls   map[cacheKey]*cacheCall
+}
+
+type cacheKey struct {
+	obj types.ManagedObjectReference
+	ps  string
+}
+
+type cacheEntry struct {
+	content types.ObjectContent
+	expires time.Time
+}
+
+// cacheCall is a retrieval in progress, shared by concurrent Get calls for the same key.
+type cacheCall struct {
+	gen     uint64
+	done    chan struct{}
+	content []types.ObjectContent
+	err     error
+}
+
+// NewCache returns a Cache that retrieves properties using the given Collector.
+func NewCache(c *Collector) *Cache {
+	return &Cache{
+		c:       c,
+		entries: make(map[cacheKey]cacheEntry),
+		calls:   make(map[cacheKey]*cacheCall),
+	}
+}
+
+func newCacheKey(obj types.ManagedObjectReference, ps []string) cacheKey {
+	p := append([]string(nil), ps...)
+	sort.Strings(p)
+	return cacheKey{obj, strings.Join(p, ",")}
+}
+
+// Get loads the properties ps of obj into dst, as Collector.RetrieveOne does.
+// The properties are served from the cache if they were retrieved less than ttl ago,
+// otherwise they are retrieved from the server and cached.
+// Concurrent calls for the same obj and ps share a single retrieval.
+// If the Context of the call performing the retrieval is canceled, the other calls retry with their own Context.
+func (c *Cache) Get(ctx context.Context, obj types.ManagedObjectReference, ps []string, dst interface{}, ttl time.Duration) error {
+	content, err := c.get(ctx, newCacheKey(obj, ps), ps, ttl)
+	if err != nil {
+		return err
+	}
+
+	if d, ok := dst.(*[]types.ObjectContent); ok {
+		*d = content
+		return nil
+	}
+
+	return mo.LoadObjectContent(content, dst)
+}
+
+func (c *Cache) get(ctx context.Context, key cacheKey, ps []string, ttl time.Duration) ([]types.ObjectContent, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		if now.Before(entry.expires) {
+			c.mu.Unlock()
+			return entry.copy(), nil
+		}
+		delete(c.entries, key)
+	}
+
+	call, ok := c.calls[key]
+	if !ok {
+		call = &cacheCall{gen: c.gen, done: make(chan struct{})}
+		c.calls[key] = call
+	}
+	c.mu.Unlock()
+
+	if ok {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if isContextError(call.err) && ctx.Err() == nil {
+			// The Context of the call that performed the retrieval was canceled, but ours was not
+			return c.get(ctx, key, ps, ttl)
+		}
+	} else {
+		call.err = c.c.RetrieveOne(ctx, key.obj, ps, &call.content)
+
+		c.mu.Lock()
+		if c.calls[key] == call {
+			delete(c.calls, key)
+		}
+		// Results of a retrieval that started before a call to Invalidate may be stale and are not cached
+		if call.err == nil && len(call.content) != 0 && call.gen == c.gen {
+			c.sweep(now)
+			c.entries[key] = cacheEntry{
+				content: call.content[0],
+				expires: time.Now().Add(ttl),
+			}
+		}
+		c.mu.Unlock()
+
+		close(call.done)
+	}
+
+	if call.err != nil || len(call.content) == 0 {
+		return call.content, call.err
+	}
+
+	return cacheEntry{content: call.content[0]}.copy(), nil
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// sweep removes expired entries, such that entries which are not read again do not accumulate.
+// The caller must hold c.mu.
+func (c *Cache) sweep(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// copy returns a deep copy of the cached content, such that callers cannot modify the cache.
+func (e cacheEntry) copy() []types.ObjectContent {
+	content := deepCopy(reflect.ValueOf(e.content)).Interface().(types.ObjectContent)
+	return []types.ObjectContent{content}
+}
+
+// deepCopy returns a copy of v, with the values referenced by its pointers, slices, maps and interfaces also copied.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		dst := reflect.New(v.Type().Elem())
+		dst.Elem().Set(deepCopy(v.Elem()))
+		return dst
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		dst := reflect.New(v.Type()).Elem()
+		dst.Set(deepCopy(v.Elem()))
+		return dst
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		dst := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			dst.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return dst
+	case reflect.Array:
+		dst := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			dst.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return dst
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		dst := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, key := range v.MapKeys() {
+			dst.SetMapIndex(key, deepCopy(v.MapIndex(key)))
+		}
+		return dst
+	case reflect.Struct:
+		dst := reflect.New(v.Type()).Elem()
+		dst.Set(v) // unexported fields, such as those of time.Time, are copied by value
+		for i := 0; i < v.NumField(); i++ {
+			if f := dst.Field(i); f.CanSet() {
+				f.Set(deepCopy(v.Field(i)))
+			}
+		}
+		return dst
+	default:
+		return v
+	}
+}
+
+// Invalidate removes any cached properties of obj, such that the next Get will retrieve them from the server.
+// Retrievals in progress do not cache their results, as they may have started before obj was changed.
+func (c *Cache) Invalidate(obj types.ManagedObjectReference) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+
+	for key := range c.entries {
+		if key.obj == obj {
+			delete(c.entries, key)
+		}
+	}
+
+	for key := range c.calls {
+		if key.obj == obj {
+			delete(c.calls, key)
+		}
+	}
+}
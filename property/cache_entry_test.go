@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestCacheSweep(t *testing.T) {
+	c := NewCache(nil)
+	now := time.Now()
+
+	vm := func(id string) types.ManagedObjectReference {
+		return types.ManagedObjectReference{Type: "VirtualMachine", Value: id}
+	}
+
+	c.entries[newCacheKey(vm("vm-1"), nil)] = cacheEntry{expires: now.Add(-time.Second)}
+	c.entries[newCacheKey(vm("vm-2"), nil)] = cacheEntry{expires: now}
+	c.entries[newCacheKey(vm("vm-3"), nil)] = cacheEntry{expires: now.Add(time.Hour)}
+
+	c.sweep(now)
+
+	if len(c.entries) != 1 {
+		t.Fatalf("entries=%d", len(c.entries))
+	}
+
+	if _, ok := c.entries[newCacheKey(vm("vm-3"), nil)]; !ok {
+		t.Error("unexpired entry removed")
+	}
+}
+
+func TestCacheEntryCopy(t *testing.T) {
+	e := cacheEntry{
+		content: types.ObjectContent{
+			PropSet: []types.DynamicProperty{{Name: "name", Val: "vm-1"}},
+		},
+	}
+
+	content := e.copy()
+	content[0].PropSet[0].Val = "modified"
+
+	if e.content.PropSet[0].Val != "vm-1" {
+		t.Errorf("cached content modified: %v", e.content.PropSet[0].Val)
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestCache(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		cache := property.NewCache(property.DefaultCollector(c))
+
+		vm := simulator.Map.Any("VirtualMachine").(*simulator.VirtualMachine)
+		ref := vm.Reference()
+		name := vm.Name
+
+		get := func(ttl time.Duration) string {
+			var content mo.VirtualMachine
+			err := cache.Get(ctx, ref, []string{"name"}, &content, ttl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return content.Name
+		}
+
+		if n := get(time.Hour); n != name {
+			t.Fatalf("name=%s", n)
+		}
+
+		simulator.Map.Update(vm, []types.PropertyChange{{Name: "name", Val: "renamed"}})
+
+		if n := get(time.Hour); n != name {
+			t.Errorf("expected cached name, got %s", n)
+		}
+
+		cache.Invalidate(ref)
+
+		if n := get(0); n != "renamed" {
+			t.Errorf("expected renamed, got %s", n)
+		}
+
+		simulator.Map.Update(vm, []types.PropertyChange{{Name: "name", Val: name}})
+
+		if n := get(time.Hour); n != name {
+			t.Errorf("expected expired entry to refresh, got %s", n)
+		}
+	})
+}
+
+// retrieveCounter counts RetrievePropertiesEx calls, delaying each to overlap concurrent callers
+type retrieveCounter struct {
+	soap.RoundTripper
+	n int32
+}
+
+func (r *retrieveCounter) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	if _, ok := req.(*methods.RetrievePropertiesExBody); ok {
+		atomic.AddInt32(&r.n, 1)
+		time.Sleep(50 * time.Millisecond)
+	}
+	return r.RoundTripper.RoundTrip(ctx, req, res)
+}
+
+func TestCacheSingleFlight(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		rt := &retrieveCounter{RoundTripper: c.RoundTripper}
+		c.RoundTripper = rt
+		cache := property.NewCache(property.DefaultCollector(c))
+
+		ref := simulator.Map.Any("VirtualMachine").Reference()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var content []types.ObjectContent
+				if err := cache.Get(ctx, ref, []string{"name"}, &content, time.Hour); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if n := atomic.LoadInt32(&rt.n); n != 1 {
+			t.Errorf("%d RetrievePropertiesEx calls", n)
+		}
+
+		// Modifying the returned content must not modify the cache
+		var content []types.ObjectContent
+		if err := cache.Get(ctx, ref, []string{"name"}, &content, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		name := content[0].PropSet[0].Val
+		content[0].PropSet[0].Val = "modified"
+
+		content = nil
+		if err := cache.Get(ctx, ref, []string{"name"}, &content, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if content[0].PropSet[0].Val != name {
+			t.Errorf("cached name=%v", content[0].PropSet[0].Val)
+		}
+	})
+}
+
+// blockingRetriever blocks the first RetrievePropertiesEx call until release is closed or its Context is done
+type blockingRetriever struct {
+	soap.RoundTripper
+	n       int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingRetriever) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	if _, ok := req.(*methods.RetrievePropertiesExBody); ok {
+		if atomic.AddInt32(&r.n, 1) == 1 {
+			close(r.started)
+			select {
+			case <-r.release:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return r.RoundTripper.RoundTrip(ctx, req, res)
+}
+
+func TestCacheCanceled(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		rt := &blockingRetriever{
+			RoundTripper: c.RoundTripper,
+			started:      make(chan struct{}),
+			release:      make(chan struct{}),
+		}
+		c.RoundTripper = rt
+		cache := property.NewCache(property.DefaultCollector(c))
+
+		ref := simulator.Map.Any("VirtualMachine").Reference()
+
+		cctx, cancel := context.WithCancel(ctx)
+		leader := make(chan error)
+		go func() {
+			var content []types.ObjectContent
+			leader <- cache.Get(cctx, ref, []string{"name"}, &content, time.Hour)
+		}()
+		<-rt.started
+
+		waiter := make(chan error)
+		go func() {
+			var content []types.ObjectContent
+			waiter <- cache.Get(ctx, ref, []string{"name"}, &content, time.Hour)
+		}()
+		time.Sleep(50 * time.Millisecond) // let the waiter join the retrieval in progress
+
+		cancel()
+		if err := <-leader; err == nil {
+			t.Error("expected error")
+		}
+
+		// The waiter's Context is not canceled, it should retry rather than fail
+		if err := <-waiter; err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestCacheInvalidateInProgress(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		rt := &blockingRetriever{
+			RoundTripper: c.RoundTripper,
+			started:      make(chan struct{}),
+			release:      make(chan struct{}),
+		}
+		c.RoundTripper = rt
+		cache := property.NewCache(property.DefaultCollector(c))
+
+		vm := simulator.Map.Any("VirtualMachine").(*simulator.VirtualMachine)
+		ref := vm.Reference()
+		name := vm.Name
+
+		get := func() string {
+			var content mo.VirtualMachine
+			if err := cache.Get(ctx, ref, []string{"name"}, &content, time.Hour); err != nil {
+				t.Fatal(err)
+			}
+			return content.Name
+		}
+
+		stale := make(chan string)
+		go func() {
+			var content mo.VirtualMachine
+			if err := cache.Get(ctx, ref, []string{"name"}, &content, time.Hour); err != nil {
+				t.Error(err)
+			}
+			stale <- content.Name
+		}()
+		<-rt.started
+
+		simulator.Map.Update(vm, []types.PropertyChange{{Name: "name", Val: "renamed"}})
+		cache.Invalidate(ref)
+		close(rt.release)
+
+		if n := <-stale; n != name && n != "renamed" {
+			t.Errorf("name=%s", n)
+		}
+
+		// The retrieval that started before Invalidate must not have been cached
+		if n := get(); n != "renamed" {
+			t.Errorf("expected renamed, got %s", n)
+		}
+
+		if n := atomic.LoadInt32(&rt.n); n != 2 {
+			t.Errorf("%d RetrievePropertiesEx calls", n)
+		}
+	})
+}
+
+func TestCacheDeepCopy(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		cache := property.NewCache(property.DefaultCollector(c))
+
+		ref := simulator.Map.Any("HostSystem").Reference()
+
+		get := func() mo.HostSystem {
+			var host mo.HostSystem
+			if err := cache.Get(ctx, ref, []string{"vm", "summary.hardware"}, &host, time.Hour); err != nil {
+				t.Fatal(err)
+			}
+			return host
+		}
+
+		host := get()
+		if len(host.Vm) == 0 || host.Summary.Hardware == nil {
+			t.Fatalf("host=%#v", host)
+		}
+		vm := host.Vm[0]
+		vendor := host.Summary.Hardware.Vendor
+
+		// Modifying values referenced by the returned content must not modify the cache
+		host.Vm[0].Value = "modified"
+		host.Summary.Hardware.Vendor = "modified"
+
+		host = get()
+		if host.Vm[0] != vm {
+			t.Errorf("cached vm=%s", host.Vm[0])
+		}
+		if host.Summary.Hardware.Vendor != vendor {
+			t.Errorf("cached vendor=%s", host.Summary.Hardware.Vendor)
+		}
+	})
+}
@@ -19,6 +19,7 @@ package property
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
@@ -117,9 +118,52 @@ func (p *Collector) CancelWaitForUpdates(ctx context.Context) error {
 	return err
 }
 
+// RetrieveProperties calls RetrievePropertiesEx, following any ContinueRetrievePropertiesEx tokens,
+// such that the response contains the complete set of objects even when the server limits the result size.
 func (p *Collector) RetrieveProperties(ctx context.Context, req types.RetrieveProperties) (*types.RetrievePropertiesResponse, error) {
+	return p.RetrievePropertiesEx(ctx, types.RetrievePropertiesEx{SpecSet: req.SpecSet})
+}
+
+// RetrievePropertiesEx is like RetrieveProperties, with the given req.Options,
+// where Options.MaxObjects limits the number of objects returned per call.
+// If a ContinueRetrievePropertiesEx call fails, the remaining results are released
+// via CancelRetrievePropertiesEx.
+func (p *Collector) RetrievePropertiesEx(ctx context.Context, req types.RetrievePropertiesEx) (*types.RetrievePropertiesResponse, error) {
 	req.This = p.Reference()
-	return methods.RetrieveProperties(ctx, p.roundTripper, &req)
+
+	res, err := methods.RetrievePropertiesEx(ctx, p.roundTripper, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Returnval == nil {
+		return &types.RetrievePropertiesResponse{}, nil
+	}
+
+	objects := res.Returnval.Objects
+	token := res.Returnval.Token
+
+	for token != "" {
+		cx := types.ContinueRetrievePropertiesEx{
+			This:  p.Reference(),
+			Token: token,
+		}
+
+		cres, err := methods.ContinueRetrievePropertiesEx(ctx, p.roundTripper, &cx)
+		if err != nil {
+			// ctx may be done, use a background context to release the server side results
+			_, _ = methods.CancelRetrievePropertiesEx(context.Background(), p.roundTripper, &types.CancelRetrievePropertiesEx{
+				This:  p.Reference(),
+				Token: token,
+			})
+			return nil, err
+		}
+
+		objects = append(objects, cres.Returnval.Objects...)
+		token = cres.Returnval.Token
+	}
+
+	return &types.RetrievePropertiesResponse{Returnval: objects}, nil
 }
 
 // Retrieve loads properties for a slice of managed objects. The dst argument
@@ -128,6 +172,8 @@ func (p *Collector) RetrieveProperties(ctx context.Context, req types.RetrievePr
 // the properties slice is nil, all properties are loaded.
 // Note that pointer types are optional fields that may be left as a nil value.
 // The caller should check such fields for a nil value before dereferencing.
+// If no objects match, a slice dst is set to an empty non-nil slice and a single
+// object dst results in mo.ErrNoObjects, see mo.LoadObjectContent.
 func (p *Collector) Retrieve(ctx context.Context, objs []types.ManagedObjectReference, ps []string, dst interface{}) error {
 	if len(objs) == 0 {
 		return errors.New("object references is empty")
@@ -182,6 +228,100 @@ func (p *Collector) Retrieve(ctx context.Context, objs []types.ManagedObjectRefe
 	return mo.LoadObjectContent(res.Returnval, dst)
 }
 
+// RetrieveConcurrent is similar to Retrieve, but splits objs into chunks of up to chunkSize objects,
+// each retrieved by a RetrieveProperties call, with up to parallelism calls running concurrently.
+// The first error cancels the remaining calls. Results are loaded into dst in the same order as objs.
+func (p *Collector) RetrieveConcurrent(ctx context.Context, objs []types.ManagedObjectReference, ps []string, chunkSize, parallelism int, dst interface{}) error {
+	if len(objs) == 0 {
+		return errors.New("object references is empty")
+	}
+
+	if chunkSize < 1 {
+		chunkSize = len(objs)
+	}
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var chunks [][]types.ManagedObjectReference
+	for i := 0; i < len(objs); i += chunkSize {
+		end := i + chunkSize
+		if end > len(objs) {
+			end = len(objs)
+		}
+		chunks = append(chunks, objs[i:end])
+	}
+
+	if parallelism > len(chunks) {
+		parallelism = len(chunks)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var rerr error
+	results := make([][]types.ObjectContent, len(chunks))
+
+	work := make(chan int, len(chunks))
+	for i := range chunks {
+		work <- i
+	}
+	close(work)
+
+	for n := 0; n < parallelism; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range work {
+				if ctx.Err() != nil {
+					return
+				}
+
+				err := p.Retrieve(ctx, chunks[i], ps, &results[i])
+				if err != nil {
+					once.Do(func() {
+						rerr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if rerr != nil {
+		return rerr
+	}
+
+	index := make(map[types.ManagedObjectReference]types.ObjectContent, len(objs))
+	for _, result := range results {
+		for _, content := range result {
+			index[content.Obj] = content
+		}
+	}
+
+	content := make([]types.ObjectContent, 0, len(index))
+	for _, obj := range objs {
+		if c, ok := index[obj]; ok {
+			content = append(content, c)
+			delete(index, obj) // in case of duplicate references
+		}
+	}
+
+	if d, ok := dst.(*[]types.ObjectContent); ok {
+		*d = content
+		return nil
+	}
+
+	return mo.LoadObjectContent(content, dst)
+}
+
 // RetrieveWithFilter populates dst as Retrieve does, but only for entities matching the given filter.
 func (p *Collector) RetrieveWithFilter(ctx context.Context, objs []types.ManagedObjectReference, ps []string, dst interface{}, filter Filter) error {
 	if len(filter) == 0 {
@@ -205,6 +345,9 @@ func (p *Collector) RetrieveWithFilter(ctx context.Context, objs []types.Managed
 }
 
 // RetrieveOne calls Retrieve with a single managed object reference via Collector.Retrieve().
+// The dst argument can be a pointer to a single mo type, such as *mo.VirtualMachine.
+// If the object no longer exists, the error is a soap fault of type types.ManagedObjectNotFound,
+// which can be detected using soap.ToSoapFault(err).VimFault().
 func (p *Collector) RetrieveOne(ctx context.Context, obj types.ManagedObjectReference, ps []string, dst interface{}) error {
 	var objs = []types.ManagedObjectReference{obj}
 	return p.Retrieve(ctx, objs, ps, dst)
@@ -0,0 +1,267 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestRetrieveOneNotFound(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		pc := property.DefaultCollector(c)
+
+		vm := simulator.Map.Any("VirtualMachine").(*simulator.VirtualMachine)
+
+		var content mo.VirtualMachine
+		err := pc.RetrieveOne(ctx, vm.Reference(), []string{"name"}, &content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if content.Name != vm.Name {
+			t.Errorf("name=%q", content.Name)
+		}
+
+		ref := types.ManagedObjectReference{Type: "VirtualMachine", Value: "enoent"}
+		err = pc.RetrieveOne(ctx, ref, []string{"name"}, &content)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		if !soap.IsSoapFault(err) {
+			t.Fatalf("expected soap fault: %s", err)
+		}
+
+		if _, ok := soap.ToSoapFault(err).VimFault().(types.ManagedObjectNotFound); !ok {
+			t.Errorf("unexpected fault: %T", soap.ToSoapFault(err).VimFault())
+		}
+	})
+}
+
+// retrieveRecorder records the largest number of objects in, and of concurrent, RetrievePropertiesEx calls
+type retrieveRecorder struct {
+	soap.RoundTripper
+
+	mu         sync.Mutex
+	active     int
+	maxActive  int
+	maxObjects int
+}
+
+func (r *retrieveRecorder) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	body, ok := req.(*methods.RetrievePropertiesExBody)
+	if !ok {
+		return r.RoundTripper.RoundTrip(ctx, req, res)
+	}
+
+	r.mu.Lock()
+	r.active++
+	if r.active > r.maxActive {
+		r.maxActive = r.active
+	}
+	for _, spec := range body.Req.SpecSet {
+		if n := len(spec.ObjectSet); n > r.maxObjects {
+			r.maxObjects = n
+		}
+	}
+	r.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond) // overlap concurrent calls
+
+	defer func() {
+		r.mu.Lock()
+		r.active--
+		r.mu.Unlock()
+	}()
+
+	return r.RoundTripper.RoundTrip(ctx, req, res)
+}
+
+func TestRetrieveConcurrent(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		pc := property.DefaultCollector(c)
+
+		var objs []types.ManagedObjectReference
+		for _, e := range simulator.Map.All("VirtualMachine") {
+			objs = append(objs, e.Reference())
+		}
+		for _, e := range simulator.Map.All("HostSystem") {
+			objs = append(objs, e.Reference())
+		}
+
+		tests := []struct {
+			chunkSize   int
+			parallelism int
+		}{
+			{0, 0},
+			{1, 1},
+			{2, 3},
+			{3, 1},
+			{len(objs), 4},
+			{len(objs) * 2, len(objs) * 2},
+		}
+
+		for _, test := range tests {
+			rt := &retrieveRecorder{RoundTripper: c.RoundTripper}
+			pc := property.DefaultCollector(&vim25.Client{Client: c.Client, RoundTripper: rt, ServiceContent: c.ServiceContent})
+
+			var entities []mo.ManagedEntity
+			err := pc.RetrieveConcurrent(ctx, objs, []string{"name"}, test.chunkSize, test.parallelism, &entities)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(entities) != len(objs) {
+				t.Fatalf("%v: %d entities", test, len(entities))
+			}
+
+			for i := range objs {
+				if entities[i].Self != objs[i] {
+					t.Errorf("%v: %d=%s", test, i, entities[i].Self)
+				}
+				if entities[i].Name == "" {
+					t.Errorf("%v: %s has no name", test, entities[i].Self)
+				}
+			}
+
+			max, parallelism := test.chunkSize, test.parallelism
+			if max < 1 {
+				max = len(objs)
+			}
+			if parallelism < 1 {
+				parallelism = 1
+			}
+			if rt.maxObjects > max {
+				t.Errorf("%v: %d objects in a single call", test, rt.maxObjects)
+			}
+			if rt.maxActive > parallelism {
+				t.Errorf("%v: %d concurrent calls", test, rt.maxActive)
+			}
+		}
+
+		bad := append([]types.ManagedObjectReference{{Type: "VirtualMachine", Value: "enoent"}}, objs...)
+		var entities []mo.ManagedEntity
+		err := pc.RetrieveConcurrent(ctx, bad, []string{"name"}, 2, 2, &entities)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestRetrievePropertiesMaxObjects(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		pc := property.DefaultCollector(c)
+
+		var objs []types.ObjectSpec
+		for _, e := range simulator.Map.All("VirtualMachine") {
+			objs = append(objs, types.ObjectSpec{Obj: e.Reference()})
+		}
+
+		req := types.RetrieveProperties{
+			SpecSet: []types.PropertyFilterSpec{{
+				ObjectSet: objs,
+				PropSet:   []types.PropertySpec{{Type: "VirtualMachine", PathSet: []string{"name"}}},
+			}},
+		}
+
+		for _, max := range []int32{0, 1, 2, int32(len(objs)), int32(len(objs) + 1)} {
+			res, err := pc.RetrievePropertiesEx(ctx, types.RetrievePropertiesEx{
+				SpecSet: req.SpecSet,
+				Options: types.RetrieveOptions{MaxObjects: max},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(res.Returnval) != len(objs) {
+				t.Errorf("max=%d: %d objects", max, len(res.Returnval))
+			}
+
+			seen := make(map[types.ManagedObjectReference]bool)
+			for _, o := range res.Returnval {
+				seen[o.Obj] = true
+			}
+			if len(seen) != len(objs) {
+				t.Errorf("max=%d: %d unique objects", max, len(seen))
+			}
+		}
+	})
+}
+
+// continueFailure fails the second ContinueRetrievePropertiesEx call
+type continueFailure struct {
+	soap.RoundTripper
+	tokens []string
+}
+
+func (f *continueFailure) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	if body, ok := req.(*methods.ContinueRetrievePropertiesExBody); ok {
+		f.tokens = append(f.tokens, body.Req.Token)
+		if len(f.tokens) == 2 {
+			return errors.New("continue failure")
+		}
+	}
+	return f.RoundTripper.RoundTrip(ctx, req, res)
+}
+
+func TestRetrievePropertiesExCancel(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		rt := &continueFailure{RoundTripper: c.RoundTripper}
+		c.RoundTripper = rt
+		pc := property.DefaultCollector(c)
+
+		var objs []types.ObjectSpec
+		for _, e := range simulator.Map.All("VirtualMachine") {
+			objs = append(objs, types.ObjectSpec{Obj: e.Reference()})
+		}
+
+		_, err := pc.RetrievePropertiesEx(ctx, types.RetrievePropertiesEx{
+			SpecSet: []types.PropertyFilterSpec{{
+				ObjectSet: objs,
+				PropSet:   []types.PropertySpec{{Type: "VirtualMachine", PathSet: []string{"name"}}},
+			}},
+			Options: types.RetrieveOptions{MaxObjects: 1},
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		if len(rt.tokens) != 2 {
+			t.Fatalf("tokens=%v", rt.tokens)
+		}
+
+		// The token of the failed call should have been canceled
+		_, err = methods.ContinueRetrievePropertiesEx(ctx, rt.RoundTripper, &types.ContinueRetrievePropertiesEx{
+			This:  pc.Reference(),
+			Token: rt.tokens[1],
+		})
+		if err == nil {
+			t.Error("expected token to be canceled")
+		}
+	})
+}
@@ -0,0 +1,390 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Tracker reconciles the UpdateSets returned by WaitForUpdatesEx into the current
+// property values of each object, keyed by property path.
+// A Tracker is safe for concurrent use.
+type Tracker struct {
+	mu      sync.Mutex
+	objects map[types.ManagedObjectReference]map[string]interface{}
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{
+		objects: make(map[types.ManagedObjectReference]map[string]interface{}),
+	}
+}
+
+// ApplyUpdateSet applies each ObjectUpdate of the given UpdateSet, in order.
+func (t *Tracker) ApplyUpdateSet(us types.UpdateSet) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, fs := range us.FilterSet {
+		for _, update := range fs.ObjectSet {
+			t.applyObjectUpdate(update)
+		}
+	}
+}
+
+func (t *Tracker) applyObjectUpdate(update types.ObjectUpdate) {
+	switch update.Kind {
+	case types.ObjectUpdateKindEnter:
+		t.objects[update.Obj] = make(map[string]interface{})
+	case types.ObjectUpdateKindLeave:
+		delete(t.objects, update.Obj)
+		return
+	}
+
+	props, ok := t.objects[update.Obj]
+	if !ok {
+		props = make(map[string]interface{})
+		t.objects[update.Obj] = props
+	}
+
+	for _, change := range update.ChangeSet {
+		applyPropertyChange(props, change)
+	}
+}
+
+// applyPropertyChange applies a single change to props.
+// Changes to a nested path, such as `config.hardware.numCPU`, or to an array element, such as
+// `config.hardware.device[4000]`, are applied to a copy of the parent property value when the parent is known.
+// Assigning or removing a property removes any values of its nested paths.
+func applyPropertyChange(props map[string]interface{}, change types.PropertyChange) {
+	for name := range props {
+		if isNestedPath(change.Name, name) {
+			delete(props, name)
+		}
+	}
+
+	for base := parentPath(change.Name); base != ""; base = parentPath(base) {
+		val, ok := props[base]
+		if !ok {
+			continue
+		}
+
+		if nval, ok := applyNestedChange(reflect.ValueOf(val), change.Name[len(base):], change); ok {
+			props[base] = nval.Interface()
+			return
+		}
+
+		break
+	}
+
+	switch change.Op {
+	case types.PropertyChangeOpRemove, types.PropertyChangeOpIndirectRemove:
+		delete(props, change.Name)
+	default:
+		props[change.Name] = change.Val
+	}
+}
+
+// isNestedPath returns true if name is a nested path or array element of the property parent.
+func isNestedPath(parent, name string) bool {
+	return strings.HasPrefix(name, parent+".") || strings.HasPrefix(name, parent+"[")
+}
+
+// parentPath returns the parent of a path, such as `config.hardware` for `config.hardware.numCPU`
+// and `config.hardware.device` for `config.hardware.device[4000]`, or "" if the path has no parent.
+func parentPath(name string) string {
+	if strings.HasSuffix(name, "]") {
+		if i := strings.LastIndex(name, "["); i > 0 {
+			return name[:i]
+		}
+		return ""
+	}
+
+	if i := strings.LastIndex(name, "."); i > 0 {
+		return name[:i]
+	}
+
+	return ""
+}
+
+// applyNestedChange returns a copy of v, with the change applied to the given path within v.
+// The path is relative to v, starting with a field name such as `.hardware` or an element key such as `[4000]`.
+func applyNestedChange(v reflect.Value, path string, change types.PropertyChange) (reflect.Value, bool) {
+	if path == "" {
+		return changeValue(v.Type(), change)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, ok := applyNestedChange(v.Elem(), path, change)
+		if !ok {
+			return v, false
+		}
+		nv := reflect.New(v.Type()).Elem()
+		nv.Set(elem)
+		return nv, true
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if !v.IsNil() {
+			elem.Set(v.Elem())
+		}
+		elem, ok := applyNestedChange(elem, path, change)
+		if !ok {
+			return v, false
+		}
+		nv := reflect.New(v.Type().Elem())
+		nv.Elem().Set(elem)
+		return nv, true
+	}
+
+	if path[0] == '[' {
+		end := strings.Index(path, "]")
+		if strings.HasPrefix(path, `["`) {
+			end = strings.Index(path, `"]`) + 1
+		}
+		if end <= 0 {
+			return v, false
+		}
+
+		key, rest := strings.Trim(path[1:end], `"`), path[end+1:]
+		if rest == "" {
+			val, ok := applyElementChange(v.Interface(), key, change)
+			return reflect.ValueOf(val), ok
+		}
+
+		return applyElementNestedChange(v, key, rest, change)
+	}
+
+	if path[0] != '.' || v.Kind() != reflect.Struct {
+		return v, false
+	}
+
+	name, rest := path[1:], ""
+	if i := strings.IndexAny(name, ".["); i > 0 {
+		name, rest = name[:i], name[i:]
+	}
+
+	index, ok := fieldIndex(v.Type(), name)
+	if !ok {
+		return v, false
+	}
+
+	nv := reflect.New(v.Type()).Elem()
+	nv.Set(v)
+
+	field := nv.FieldByIndex(index)
+	val, ok := applyNestedChange(field, rest, change)
+	if !ok {
+		return v, false
+	}
+	field.Set(val)
+
+	return nv, true
+}
+
+// applyElementNestedChange returns a copy of the array value v, with the change applied to the given path
+// within the element matching key, such as `.backing.fileName` of `config.hardware.device[2000]`.
+func applyElementNestedChange(v reflect.Value, key string, path string, change types.PropertyChange) (reflect.Value, bool) {
+	array := reflect.New(v.Type()).Elem()
+	array.Set(v)
+
+	slice := array
+	if array.Kind() == reflect.Struct && array.NumField() == 1 {
+		slice = array.Field(0)
+	}
+
+	if slice.Kind() != reflect.Slice {
+		return v, false
+	}
+
+	for i := 0; i < slice.Len(); i++ {
+		if elementKey(slice.Index(i)) != key {
+			continue
+		}
+
+		elems := reflect.MakeSlice(slice.Type(), slice.Len(), slice.Len())
+		reflect.Copy(elems, slice)
+
+		elem, ok := applyNestedChange(elems.Index(i), path, change)
+		if !ok {
+			return v, false
+		}
+		elems.Index(i).Set(elem)
+		slice.Set(elems)
+
+		return array, true
+	}
+
+	return v, false
+}
+
+// changeValue returns the value of change as type t, or the zero value of t if the change is a removal.
+// Values of ArrayOf* types are unwrapped when t is the slice type they wrap.
+func changeValue(t reflect.Type, change types.PropertyChange) (reflect.Value, bool) {
+	nv := reflect.New(t).Elem()
+
+	switch change.Op {
+	case types.PropertyChangeOpRemove, types.PropertyChangeOpIndirectRemove:
+		return nv, true
+	}
+
+	val := reflect.ValueOf(change.Val)
+	switch {
+	case !val.IsValid():
+	case val.Type().AssignableTo(t):
+		nv.Set(val)
+	case t.Kind() == reflect.Ptr && val.Type().AssignableTo(t.Elem()):
+		nv.Set(reflect.New(t.Elem()))
+		nv.Elem().Set(val)
+	case val.Kind() == reflect.Struct && val.NumField() == 1 && val.Field(0).Type().AssignableTo(t):
+		nv.Set(val.Field(0))
+	default:
+		return nv, false
+	}
+
+	return nv, true
+}
+
+// fieldIndex returns the index of the exported field of struct type t with the given xml name,
+// including fields of embedded structs, such as the Key field of types.VirtualDevice within types.VirtualDisk.
+func fieldIndex(t reflect.Type, name string) ([]int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if index, ok := fieldIndex(f.Type, name); ok {
+				return append([]int{i}, index...), true
+			}
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if strings.Split(f.Tag.Get("xml"), ",")[0] == name {
+			return []int{i}, true
+		}
+	}
+
+	return nil, false
+}
+
+// applyElementChange returns a copy of the array value val, with the element matching key changed.
+// The array value is either a slice or an ArrayOf* struct type wrapping a slice.
+func applyElementChange(val interface{}, key string, change types.PropertyChange) (interface{}, bool) {
+	rv := reflect.ValueOf(val)
+	if !rv.IsValid() {
+		return nil, false
+	}
+
+	array := reflect.New(rv.Type()).Elem()
+	array.Set(rv)
+
+	slice := array
+	if array.Kind() == reflect.Struct {
+		if array.NumField() != 1 {
+			return nil, false
+		}
+		slice = array.Field(0)
+	}
+
+	if slice.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	index := -1
+	for i := 0; i < slice.Len(); i++ {
+		if elementKey(slice.Index(i)) == key {
+			index = i
+			break
+		}
+	}
+
+	elems := reflect.MakeSlice(slice.Type(), 0, slice.Len()+1)
+
+	switch change.Op {
+	case types.PropertyChangeOpRemove, types.PropertyChangeOpIndirectRemove:
+		for i := 0; i < slice.Len(); i++ {
+			if i != index {
+				elems = reflect.Append(elems, slice.Index(i))
+			}
+		}
+	default:
+		ev := reflect.ValueOf(change.Val)
+		if !ev.IsValid() || !ev.Type().AssignableTo(slice.Type().Elem()) {
+			return nil, false
+		}
+
+		elems = reflect.AppendSlice(elems, slice)
+		if index == -1 {
+			elems = reflect.Append(elems, ev)
+		} else {
+			elems.Index(index).Set(ev)
+		}
+	}
+
+	slice.Set(elems)
+
+	return array.Interface(), true
+}
+
+// elementKey returns the value of the element's Key field if it has one, otherwise the element value itself.
+func elementKey(v reflect.Value) string {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Struct {
+		if key := v.FieldByName("Key"); key.IsValid() {
+			return fmt.Sprint(key.Interface())
+		}
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// Current returns a copy of the reconciled property values of the given object,
+// or nil if the object is not known to the Tracker.
+func (t *Tracker) Current(obj types.ManagedObjectReference) map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	props, ok := t.objects[obj]
+	if !ok {
+		return nil
+	}
+
+	current := make(map[string]interface{}, len(props))
+	for name, val := range props {
+		current[name] = val
+	}
+
+	return current
+}
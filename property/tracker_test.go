@@ -0,0 +1,206 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property
+
+import (
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestTracker(t *testing.T) {
+	vm := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"}
+
+	disk := func(key int32, capacity int64) types.BaseVirtualDevice {
+		return &types.VirtualDisk{
+			VirtualDevice: types.VirtualDevice{Key: key},
+			CapacityInKB:  capacity,
+		}
+	}
+
+	update := func(kind types.ObjectUpdateKind, changes ...types.PropertyChange) types.UpdateSet {
+		return types.UpdateSet{
+			FilterSet: []types.PropertyFilterUpdate{{
+				ObjectSet: []types.ObjectUpdate{{
+					Kind:      kind,
+					Obj:       vm,
+					ChangeSet: changes,
+				}},
+			}},
+		}
+	}
+
+	devices := func(tr *Tracker) []types.BaseVirtualDevice {
+		return tr.Current(vm)["config.hardware.device"].(types.ArrayOfVirtualDevice).VirtualDevice
+	}
+
+	tr := NewTracker()
+
+	tr.ApplyUpdateSet(update(types.ObjectUpdateKindEnter,
+		types.PropertyChange{Name: "name", Op: types.PropertyChangeOpAssign, Val: "vm1"},
+		types.PropertyChange{Name: "config.hardware.device", Op: types.PropertyChangeOpAssign, Val: types.ArrayOfVirtualDevice{
+			VirtualDevice: []types.BaseVirtualDevice{disk(2000, 1), disk(2001, 1)},
+		}},
+	))
+
+	if name := tr.Current(vm)["name"]; name != "vm1" {
+		t.Errorf("name=%v", name)
+	}
+
+	tr.ApplyUpdateSet(update(types.ObjectUpdateKindModify,
+		types.PropertyChange{Name: "name", Op: types.PropertyChangeOpAssign, Val: "vm2"},
+		types.PropertyChange{Name: "config.hardware.device[2000]", Op: types.PropertyChangeOpAssign, Val: disk(2000, 2)},
+		types.PropertyChange{Name: "config.hardware.device[2002]", Op: types.PropertyChangeOpAdd, Val: disk(2002, 3)},
+		types.PropertyChange{Name: "config.hardware.device[2001]", Op: types.PropertyChangeOpRemove},
+	))
+
+	if name := tr.Current(vm)["name"]; name != "vm2" {
+		t.Errorf("name=%v", name)
+	}
+
+	d := devices(tr)
+	if len(d) != 2 {
+		t.Fatalf("devices=%d", len(d))
+	}
+	if d[0].(*types.VirtualDisk).CapacityInKB != 2 || d[1].GetVirtualDevice().Key != 2002 {
+		t.Errorf("devices=%#v", d)
+	}
+
+	tr.ApplyUpdateSet(update(types.ObjectUpdateKindModify,
+		types.PropertyChange{Name: "name", Op: types.PropertyChangeOpRemove},
+	))
+
+	if _, ok := tr.Current(vm)["name"]; ok {
+		t.Error("name not removed")
+	}
+
+	tr.ApplyUpdateSet(update(types.ObjectUpdateKindLeave))
+
+	if tr.Current(vm) != nil {
+		t.Error("object not removed")
+	}
+}
+
+func TestTrackerNestedPath(t *testing.T) {
+	vm := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"}
+
+	update := func(changes ...types.PropertyChange) types.UpdateSet {
+		return types.UpdateSet{
+			FilterSet: []types.PropertyFilterUpdate{{
+				ObjectSet: []types.ObjectUpdate{{
+					Kind:      types.ObjectUpdateKindModify,
+					Obj:       vm,
+					ChangeSet: changes,
+				}},
+			}},
+		}
+	}
+
+	config := func(tr *Tracker) types.VirtualMachineConfigInfo {
+		return tr.Current(vm)["config"].(types.VirtualMachineConfigInfo)
+	}
+
+	tr := NewTracker()
+
+	tr.ApplyUpdateSet(update(
+		types.PropertyChange{Name: "config", Op: types.PropertyChangeOpAssign, Val: types.VirtualMachineConfigInfo{
+			Name: "vm1",
+			Hardware: types.VirtualHardware{
+				NumCPU: 1,
+				Device: []types.BaseVirtualDevice{
+					&types.VirtualDisk{VirtualDevice: types.VirtualDevice{Key: 2000}, CapacityInKB: 1},
+				},
+			},
+		}},
+		types.PropertyChange{Name: "summary.runtime.powerState", Op: types.PropertyChangeOpAssign, Val: types.VirtualMachinePowerStatePoweredOff},
+	))
+
+	before := config(tr)
+
+	tr.ApplyUpdateSet(update(
+		types.PropertyChange{Name: "config.hardware.numCPU", Op: types.PropertyChangeOpAssign, Val: int32(4)},
+		types.PropertyChange{Name: "config.cpuAllocation.reservation", Op: types.PropertyChangeOpAssign, Val: int64(100)},
+		types.PropertyChange{Name: "config.hardware.device[2000].capacityInKB", Op: types.PropertyChangeOpAssign, Val: int64(2)},
+		types.PropertyChange{Name: "config.hardware.device[2001]", Op: types.PropertyChangeOpAdd, Val: &types.VirtualDisk{
+			VirtualDevice: types.VirtualDevice{Key: 2001},
+			CapacityInKB:  3,
+		}},
+	))
+
+	props := tr.Current(vm)
+	for _, name := range []string{"config.hardware.numCPU", "config.cpuAllocation.reservation", "config.hardware.device[2000].capacityInKB"} {
+		if _, ok := props[name]; ok {
+			t.Errorf("%s stored as its own property", name)
+		}
+	}
+
+	c := config(tr)
+	if c.Name != "vm1" {
+		t.Errorf("name=%s", c.Name)
+	}
+	if c.Hardware.NumCPU != 4 {
+		t.Errorf("numCPU=%d", c.Hardware.NumCPU)
+	}
+	if c.CpuAllocation == nil || c.CpuAllocation.Reservation == nil || *c.CpuAllocation.Reservation != 100 {
+		t.Errorf("cpuAllocation=%#v", c.CpuAllocation)
+	}
+	if len(c.Hardware.Device) != 2 {
+		t.Fatalf("devices=%d", len(c.Hardware.Device))
+	}
+	if n := c.Hardware.Device[0].(*types.VirtualDisk).CapacityInKB; n != 2 {
+		t.Errorf("capacityInKB=%d", n)
+	}
+	if key := c.Hardware.Device[1].GetVirtualDevice().Key; key != 2001 {
+		t.Errorf("key=%d", key)
+	}
+
+	// Values returned by Current are not modified by later changes
+	if before.Hardware.NumCPU != 1 || before.CpuAllocation != nil || len(before.Hardware.Device) != 1 {
+		t.Errorf("config=%#v", before)
+	}
+	if n := before.Hardware.Device[0].(*types.VirtualDisk).CapacityInKB; n != 1 {
+		t.Errorf("capacityInKB=%d", n)
+	}
+
+	// Assigning a parent removes its nested paths
+	tr.ApplyUpdateSet(update(
+		types.PropertyChange{Name: "summary.runtime", Op: types.PropertyChangeOpAssign, Val: types.VirtualMachineRuntimeInfo{
+			PowerState: types.VirtualMachinePowerStatePoweredOn,
+		}},
+	))
+
+	props = tr.Current(vm)
+	if _, ok := props["summary.runtime.powerState"]; ok {
+		t.Error("summary.runtime.powerState not removed")
+	}
+	if state := props["summary.runtime"].(types.VirtualMachineRuntimeInfo).PowerState; state != types.VirtualMachinePowerStatePoweredOn {
+		t.Errorf("powerState=%s", state)
+	}
+
+	// Removing a parent removes its nested paths
+	tr.ApplyUpdateSet(update(
+		types.PropertyChange{Name: "summary.runtime.powerState", Op: types.PropertyChangeOpAssign, Val: types.VirtualMachinePowerStatePoweredOff},
+		types.PropertyChange{Name: "summary", Op: types.PropertyChangeOpRemove},
+	))
+
+	props = tr.Current(vm)
+	for name := range props {
+		if isNestedPath("summary", name) {
+			t.Errorf("%s not removed", name)
+		}
+	}
+}
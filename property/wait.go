@@ -18,6 +18,9 @@ package property
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/soap"
@@ -70,6 +73,127 @@ func Wait(ctx context.Context, c *Collector, obj types.ManagedObjectReference, p
 	})
 }
 
+// WaitForPropertiesN is like Wait, but for a set of objects, which may be of different types.
+// It creates a single WaitFilter for the objects and calls the specified function with the
+// changes of each updated object, until the function returns true for any object.
+func WaitForPropertiesN(ctx context.Context, c *Collector, objs []types.ManagedObjectReference, ps []string, f func(types.ManagedObjectReference, []types.PropertyChange) bool) error {
+	filter := new(WaitFilter)
+	kinds := make(map[string]bool)
+
+	for _, obj := range objs {
+		filter.Spec.ObjectSet = append(filter.Spec.ObjectSet, types.ObjectSpec{Obj: obj})
+
+		if kinds[obj.Type] {
+			continue
+		}
+		kinds[obj.Type] = true
+
+		pset := types.PropertySpec{
+			Type:    obj.Type,
+			PathSet: ps,
+		}
+		if len(ps) == 0 {
+			pset.All = types.NewBool(true)
+		}
+		filter.Spec.PropSet = append(filter.Spec.PropSet, pset)
+	}
+
+	return WaitForObjectUpdates(ctx, c, filter, f)
+}
+
+// WaitForObjectUpdates is like WaitForUpdates, but calls the specified function
+// with the changes of each updated object. To wait for the objects of a view, add the
+// view to the filter along with its traversal spec, for example:
+//
+//	filter := new(property.WaitFilter).Add(v.Reference(), "VirtualMachine", ps, v.TraversalSpec())
+func WaitForObjectUpdates(ctx context.Context, c *Collector, filter *WaitFilter, f func(types.ManagedObjectReference, []types.PropertyChange) bool) error {
+	return WaitForUpdates(ctx, c, filter, func(updates []types.ObjectUpdate) bool {
+		for _, update := range updates {
+			if f(update.Obj, update.ChangeSet) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// TimeoutError is returned by WaitForState when the property did not reach the desired value in time.
+type TimeoutError struct {
+	Obj      types.ManagedObjectReference
+	Property string
+	Want     interface{}
+	Timeout  time.Duration
+}
+
+func (e TimeoutError) Error() string {
+	return fmt.Sprintf("timeout after %s waiting for %s %s=%v", e.Timeout, e.Obj, e.Property, e.Want)
+}
+
+// WaitForState waits for property p of obj to equal want, via Wait.
+// Values are compared using reflect.DeepEqual, after converting want to the property's type
+// when both are strings or both are integers, such that "poweredOn" matches types.VirtualMachinePowerStatePoweredOn.
+// If the Context is canceled, ctx.Err() is returned.
+// If the timeout is greater than zero and is exceeded, a TimeoutError is returned.
+func WaitForState(ctx context.Context, c *Collector, obj types.ManagedObjectReference, p string, want interface{}, timeout time.Duration) error {
+	wctx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		wctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	match := false
+
+	err := Wait(wctx, c, obj, []string{p}, func(changes []types.PropertyChange) bool {
+		for _, change := range changes {
+			if change.Name == p && stateEqual(change.Val, want) {
+				match = true
+			}
+		}
+		return match
+	})
+
+	if match {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if wctx.Err() != nil {
+		return TimeoutError{Obj: obj, Property: p, Want: want, Timeout: timeout}
+	}
+
+	return err
+}
+
+func stateEqual(val, want interface{}) bool {
+	if reflect.DeepEqual(val, want) {
+		return true
+	}
+
+	v, w := reflect.ValueOf(val), reflect.ValueOf(want)
+	if !v.IsValid() || !w.IsValid() {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if w.Kind() == reflect.String {
+			return v.String() == w.String()
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch w.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.Int() == w.Int()
+		}
+	}
+
+	return false
+}
+
 // WaitForUpdates waits for any of the specified properties of the specified managed
 // object to change. It calls the specified function for every update it
 // receives. If this function returns false, it continues waiting for
@@ -20,10 +20,14 @@ import (
 	"context"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
@@ -126,3 +130,149 @@ func TestWaitPermissionFault(t *testing.T) {
 		t.Fatalf("unexpected vim fault: %T", fault)
 	}
 }
+
+func TestWaitForState(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		pc := property.DefaultCollector(c)
+
+		vm := object.NewVirtualMachine(c, simulator.Map.Any("VirtualMachine").Reference())
+
+		err := property.WaitForState(ctx, pc, vm.Reference(), "runtime.powerState", "poweredOn", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = property.WaitForState(ctx, pc, vm.Reference(), "runtime.powerState", types.VirtualMachinePowerStatePoweredOff, time.Millisecond*100)
+		if _, ok := err.(property.TimeoutError); !ok {
+			t.Fatalf("expected TimeoutError, got %v", err)
+		}
+
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		err = property.WaitForState(ctx, pc, vm.Reference(), "runtime.powerState", types.VirtualMachinePowerStatePoweredOff, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		err = property.WaitForState(cctx, pc, vm.Reference(), "runtime.powerState", "poweredOn", time.Minute)
+		if err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestWaitForPropertiesN(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		pc := property.DefaultCollector(c)
+
+		vms := simulator.Map.All("VirtualMachine")
+		vm := object.NewVirtualMachine(c, vms[0].Reference())
+
+		objs := []types.ManagedObjectReference{
+			vms[0].Reference(),
+			vms[1].Reference(),
+			simulator.Map.Any("HostSystem").Reference(),
+		}
+
+		state := make(map[types.ManagedObjectReference]interface{})
+
+		err := property.WaitForPropertiesN(ctx, pc, objs, []string{"runtime.powerState"}, func(obj types.ManagedObjectReference, changes []types.PropertyChange) bool {
+			for _, change := range changes {
+				state[obj] = change.Val
+			}
+			return len(state) == len(objs)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if state[objs[2]] != types.HostSystemPowerStatePoweredOn {
+			t.Errorf("host state=%v", state[objs[2]])
+		}
+
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		err = property.WaitForPropertiesN(ctx, pc, objs, []string{"runtime.powerState"}, func(obj types.ManagedObjectReference, changes []types.PropertyChange) bool {
+			for _, change := range changes {
+				if obj == vm.Reference() && change.Val == types.VirtualMachinePowerStatePoweredOff {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestWaitForObjectUpdates(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		pc := property.DefaultCollector(c)
+
+		v, err := view.NewManager(c).CreateContainerView(ctx, c.ServiceContent.RootFolder, []string{"VirtualMachine"}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = v.Destroy(ctx) }()
+
+		ps := []string{"runtime.powerState"}
+		vms := simulator.Map.All("VirtualMachine")
+		state := make(map[types.ManagedObjectReference]interface{})
+
+		filter := new(property.WaitFilter).Add(v.Reference(), "VirtualMachine", ps, v.TraversalSpec())
+
+		err = property.WaitForObjectUpdates(ctx, pc, filter, func(obj types.ManagedObjectReference, changes []types.PropertyChange) bool {
+			for _, change := range changes {
+				state[obj] = change.Val
+			}
+			return len(state) == len(vms)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, vm := range vms {
+			if state[vm.Reference()] != types.VirtualMachinePowerStatePoweredOn {
+				t.Errorf("%s state=%v", vm.Reference(), state[vm.Reference()])
+			}
+		}
+
+		vm := object.NewVirtualMachine(c, vms[0].Reference())
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		filter = new(property.WaitFilter).Add(v.Reference(), "VirtualMachine", ps, v.TraversalSpec())
+
+		err = property.WaitForObjectUpdates(ctx, pc, filter, func(obj types.ManagedObjectReference, changes []types.PropertyChange) bool {
+			for _, change := range changes {
+				if obj == vm.Reference() && change.Val == types.VirtualMachinePowerStatePoweredOff {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
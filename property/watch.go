@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Watcher streams property changes from a filter created by Collector.Watch.
+type Watcher struct {
+	ch chan []types.PropertyChange
+
+	mu  sync.Mutex
+	err error
+}
+
+// Changes returns the channel on which the ChangeSet of each updated object is sent.
+// The channel is closed when the Context passed to Collector.Watch is canceled or
+// waiting for updates fails, in which case Err returns the error.
+func (w *Watcher) Changes() <-chan []types.PropertyChange {
+	return w.ch
+}
+
+// Err returns the error that terminated the Watcher, if any.
+// Context cancellation is not considered an error.
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Watch creates a new PropertyCollector with a filter for the given spec, with partial updates enabled,
+// and calls WaitForUpdatesEx in a goroutine, sending each ObjectUpdate's ChangeSet to the Watcher's Changes channel.
+// The new collector is destroyed when the Watcher terminates.
+func (p *Collector) Watch(ctx context.Context, spec types.PropertyFilterSpec) (*Watcher, error) {
+	pc, err := p.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pc.CreateFilter(ctx, types.CreateFilter{Spec: spec, PartialUpdates: true})
+	if err != nil {
+		_ = pc.Destroy(context.Background())
+		return nil, err
+	}
+
+	w := &Watcher{
+		ch: make(chan []types.PropertyChange),
+	}
+
+	go w.run(ctx, pc)
+
+	return w, nil
+}
+
+func (w *Watcher) run(ctx context.Context, pc *Collector) {
+	defer close(w.ch)
+
+	// Attempt to destroy the collector using the background context, as the
+	// specified context will have been canceled.
+	defer func() {
+		_ = pc.Destroy(context.Background())
+	}()
+
+	req := types.WaitForUpdatesEx{
+		This: pc.Reference(),
+	}
+
+	for {
+		res, err := methods.WaitForUpdatesEx(ctx, pc.roundTripper, &req)
+		if err != nil {
+			if ctx.Err() != nil {
+				_ = pc.CancelWaitForUpdates(context.Background())
+				return
+			}
+
+			w.mu.Lock()
+			w.err = err
+			w.mu.Unlock()
+			return
+		}
+
+		set := res.Returnval
+		if set == nil {
+			continue
+		}
+
+		req.Version = set.Version
+
+		for _, fs := range set.FilterSet {
+			for _, update := range fs.ObjectSet {
+				if len(update.ChangeSet) == 0 {
+					continue
+				}
+
+				select {
+				case w.ch <- update.ChangeSet:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package property_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestWatch(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		obj := simulator.Map.Any("VirtualMachine").(*simulator.VirtualMachine)
+		vm := object.NewVirtualMachine(c, obj.Reference())
+
+		spec := types.PropertyFilterSpec{
+			ObjectSet: []types.ObjectSpec{{Obj: vm.Reference()}},
+			PropSet: []types.PropertySpec{{
+				Type:    vm.Reference().Type,
+				PathSet: []string{"runtime.powerState"},
+			}},
+		}
+
+		wctx, cancel := context.WithCancel(ctx)
+
+		w, err := property.DefaultCollector(c).Watch(wctx, spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// initial state
+		changes := <-w.Changes()
+		if changes[0].Val != types.VirtualMachinePowerStatePoweredOn {
+			t.Errorf("initial=%#v", changes)
+		}
+
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		for changes = range w.Changes() {
+			if changes[0].Val == types.VirtualMachinePowerStatePoweredOff {
+				break
+			}
+		}
+
+		cancel()
+
+		for range w.Changes() {
+			// drain until closed
+		}
+
+		if err = w.Err(); err != nil {
+			t.Errorf("Err=%s", err)
+		}
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -57,9 +58,23 @@ func Secret(value string) (string, error) {
 	return strings.TrimSpace(string(contents)), nil
 }
 
+func isNotAuthenticated(err error) bool {
+	if soap.IsSoapFault(err) {
+		switch soap.ToSoapFault(err).VimFault().(type) {
+		case types.NotAuthenticated:
+			return true
+		}
+	}
+	return false
+}
+
 type Manager struct {
 	client      *vim25.Client
 	userSession *types.UserSession
+
+	// Locale is passed to the Login methods, overriding the package level Locale var when set.
+	// A value of "_" uses the server locale setting.
+	Locale string
 }
 
 func NewManager(client *vim25.Client) *Manager {
@@ -74,6 +89,17 @@ func (sm Manager) Reference() types.ManagedObjectReference {
 	return *sm.client.ServiceContent.SessionManager
 }
 
+func (sm *Manager) locale() string {
+	switch sm.Locale {
+	case "":
+		return Locale
+	case "_":
+		return ""
+	default:
+		return sm.Locale
+	}
+}
+
 func (sm *Manager) SetLocale(ctx context.Context, locale string) error {
 	req := types.SetLocale{
 		This:   sm.Reference(),
@@ -87,7 +113,7 @@ func (sm *Manager) SetLocale(ctx context.Context, locale string) error {
 func (sm *Manager) Login(ctx context.Context, u *url.Userinfo) error {
 	req := types.Login{
 		This:   sm.Reference(),
-		Locale: Locale,
+		Locale: sm.locale(),
 	}
 
 	if u != nil {
@@ -129,7 +155,7 @@ func (sm *Manager) LoginExtensionByCertificate(ctx context.Context, key string)
 	req := types.LoginExtensionByCertificate{
 		This:         sm.Reference(),
 		ExtensionKey: key,
-		Locale:       Locale,
+		Locale:       sm.locale(),
 	}
 
 	login, err := methods.LoginExtensionByCertificate(ctx, c, &req)
@@ -147,7 +173,7 @@ func (sm *Manager) LoginExtensionByCertificate(ctx context.Context, key string)
 func (sm *Manager) LoginByToken(ctx context.Context) error {
 	req := types.LoginByToken{
 		This:   sm.Reference(),
-		Locale: Locale,
+		Locale: sm.locale(),
 	}
 
 	login, err := methods.LoginByToken(ctx, sm.client, &req)
@@ -159,13 +185,15 @@ func (sm *Manager) LoginByToken(ctx context.Context) error {
 	return nil
 }
 
+// Logout terminates the current session.
+// It is safe to call Logout when not logged in, in which case the NotAuthenticated fault is ignored.
 func (sm *Manager) Logout(ctx context.Context) error {
 	req := types.Logout{
 		This: sm.Reference(),
 	}
 
 	_, err := methods.Logout(ctx, sm.client, &req)
-	if err != nil {
+	if err != nil && !isNotAuthenticated(err) {
 		return err
 	}
 
@@ -195,6 +223,23 @@ func (sm *Manager) UserSession(ctx context.Context) (*types.UserSession, error)
 	return mgr.CurrentSession, nil
 }
 
+// SessionList retrieves and returns the SessionManager's SessionList field,
+// the list of sessions currently active on the server.
+func (sm *Manager) SessionList(ctx context.Context) ([]types.UserSession, error) {
+	var mgr mo.SessionManager
+
+	pc := property.DefaultCollector(sm.client)
+	err := pc.RetrieveOne(ctx, sm.Reference(), []string{"sessionList"}, &mgr)
+	if err != nil {
+		return nil, err
+	}
+
+	return mgr.SessionList, nil
+}
+
+// TerminateSession terminates the given sessions, such as those returned by SessionList.
+// The server returns an InvalidArgument fault if sessionId includes the caller's current session,
+// use Logout to terminate the current session.
 func (sm *Manager) TerminateSession(ctx context.Context, sessionId []string) error {
 	req := types.TerminateSession{
 		This:      sm.Reference(),
@@ -205,6 +250,33 @@ func (sm *Manager) TerminateSession(ctx context.Context, sessionId []string) err
 	return err
 }
 
+// TerminateSessions terminates the given sessions, such as those returned by SessionList.
+// The caller's current session is filtered out of sessionId unless force is true.
+func (sm *Manager) TerminateSessions(ctx context.Context, sessionId []string, force bool) error {
+	if !force {
+		current, err := sm.UserSession(ctx)
+		if err != nil {
+			return err
+		}
+
+		if current != nil {
+			var ids []string
+			for _, id := range sessionId {
+				if id != current.Key {
+					ids = append(ids, id)
+				}
+			}
+			sessionId = ids
+		}
+	}
+
+	if len(sessionId) == 0 {
+		return nil
+	}
+
+	return sm.TerminateSession(ctx, sessionId)
+}
+
 // SessionIsActive checks whether the session that was created at login is
 // still valid. This function only works against vCenter.
 func (sm *Manager) SessionIsActive(ctx context.Context) (bool, error) {
@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/vmware/govmomi/vim25/soap"
+)
+
+type reloginContext struct{}
+
+type relogin struct {
+	roundTripper soap.RoundTripper
+	login        func(context.Context) error
+
+	mu  sync.Mutex
+	gen int // incremented after each successful login
+}
+
+// Relogin wraps the given RoundTripper such that a request failing with a NotAuthenticated fault,
+// for example when the session has expired, calls login and is retried once.
+// Concurrent requests failing with NotAuthenticated result in a single call to login.
+// Requests made by login itself, such as Manager.Login, are not retried.
+// Example:
+//
+//	m := session.NewManager(c)
+//	c.RoundTripper = session.Relogin(c.RoundTripper, func(ctx context.Context) error {
+//		return m.Login(ctx, u)
+//	})
+func Relogin(roundTripper soap.RoundTripper, login func(context.Context) error) soap.RoundTripper {
+	return &relogin{
+		roundTripper: roundTripper,
+		login:        login,
+	}
+}
+
+func (r *relogin) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	if ctx.Value(reloginContext{}) != nil {
+		return r.roundTripper.RoundTrip(ctx, req, res)
+	}
+
+	r.mu.Lock()
+	gen := r.gen
+	r.mu.Unlock()
+
+	err := r.roundTripper.RoundTrip(ctx, req, res)
+	if !isNotAuthenticated(err) {
+		return err
+	}
+
+	if err = r.relogin(ctx, gen); err != nil {
+		return err
+	}
+
+	// Clear the fault decoded by the failed attempt
+	rv := reflect.ValueOf(res).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+
+	return r.roundTripper.RoundTrip(ctx, req, res)
+}
+
+// relogin calls login, unless another request has already done so since gen.
+func (r *relogin) relogin(ctx context.Context, gen int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.gen != gen {
+		return nil
+	}
+
+	err := r.login(context.WithValue(ctx, reloginContext{}, true))
+	if err == nil {
+		r.gen++
+	}
+
+	return err
+}
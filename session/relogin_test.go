@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+)
+
+func TestRelogin(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		m := session.NewManager(c)
+
+		n := 0
+		c.RoundTripper = session.Relogin(c.RoundTripper, func(ctx context.Context) error {
+			n++
+			return m.Login(ctx, simulator.DefaultLogin)
+		})
+
+		err := m.Logout(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// requires authentication
+		_, err = methods.GetCurrentTime(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		us, err := m.UserSession(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if us == nil {
+			t.Fatal("not logged in")
+		}
+
+		if n != 1 {
+			t.Errorf("login called %d times", n)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"time"
+
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// AlarmManager implements alarm definitions, alarms are not triggered by vcsim.
+// An entity's TriggeredAlarmState can be set directly to simulate triggered alarms.
+type AlarmManager struct {
+	mo.AlarmManager
+}
+
+type Alarm struct {
+	mo.Alarm
+}
+
+func (m *AlarmManager) CreateAlarm(ctx *Context, req *types.CreateAlarm) soap.HasFault {
+	body := &methods.CreateAlarmBody{}
+
+	if Map.Get(req.Entity) == nil {
+		body.Fault_ = Fault("", &types.ManagedObjectNotFound{Obj: req.Entity})
+		return body
+	}
+
+	alarm := &Alarm{}
+	Map.Put(alarm)
+	alarm.Info = types.AlarmInfo{
+		AlarmSpec:        *req.Spec.GetAlarmSpec(),
+		Key:              alarm.Self.Value,
+		Alarm:            alarm.Self,
+		Entity:           req.Entity,
+		LastModifiedTime: time.Now(),
+		LastModifiedUser: ctx.Session.UserName,
+	}
+
+	body.Res = &types.CreateAlarmResponse{
+		Returnval: alarm.Self,
+	}
+
+	return body
+}
+
+func (m *AlarmManager) GetAlarm(req *types.GetAlarm) soap.HasFault {
+	var alarms []types.ManagedObjectReference
+
+	for _, obj := range Map.AllReference("Alarm") {
+		alarm := obj.(*Alarm)
+		if req.Entity == nil || *req.Entity == alarm.Info.Entity {
+			alarms = append(alarms, alarm.Self)
+		}
+	}
+
+	return &methods.GetAlarmBody{
+		Res: &types.GetAlarmResponse{
+			Returnval: alarms,
+		},
+	}
+}
+
+func (m *AlarmManager) GetAlarmState(ctx *Context, req *types.GetAlarmState) soap.HasFault {
+	body := &methods.GetAlarmStateBody{}
+
+	entity, ok := Map.Get(req.Entity).(mo.Entity)
+	if !ok {
+		body.Fault_ = Fault("", &types.ManagedObjectNotFound{Obj: req.Entity})
+		return body
+	}
+
+	var states []types.AlarmState
+	ctx.WithLock(entity, func() {
+		states = append(states, entity.Entity().TriggeredAlarmState...)
+	})
+
+	body.Res = &types.GetAlarmStateResponse{
+		Returnval: states,
+	}
+
+	return body
+}
+
+func (m *AlarmManager) AcknowledgeAlarm(ctx *Context, req *types.AcknowledgeAlarm) soap.HasFault {
+	body := &methods.AcknowledgeAlarmBody{}
+
+	entity, ok := Map.Get(req.Entity).(mo.Entity)
+	if !ok {
+		body.Fault_ = Fault("", &types.ManagedObjectNotFound{Obj: req.Entity})
+		return body
+	}
+
+	if Map.Get(req.Alarm) == nil {
+		body.Fault_ = Fault("", &types.ManagedObjectNotFound{Obj: req.Alarm})
+		return body
+	}
+
+	found := false
+
+	ctx.WithLock(entity, func() {
+		states := append([]types.AlarmState(nil), entity.Entity().TriggeredAlarmState...)
+		for i := range states {
+			if states[i].Alarm == req.Alarm {
+				now := time.Now()
+				states[i].Acknowledged = types.NewBool(true)
+				states[i].AcknowledgedByUser = ctx.Session.UserName
+				states[i].AcknowledgedTime = &now
+				found = true
+			}
+		}
+
+		if found {
+			ctx.Map.Update(entity, []types.PropertyChange{{Name: "triggeredAlarmState", Val: states}})
+		}
+	})
+
+	if !found {
+		body.Fault_ = Fault("alarm is not triggered on entity", &types.InvalidArgument{InvalidProperty: "alarm"})
+		return body
+	}
+
+	body.Res = new(types.AcknowledgeAlarmResponse)
+
+	return body
+}
+
+// alarmFilterMatch returns true if the triggered alarm state on entity e matches the given filter.
+// The filter's TypeTrigger is not supported, as vcsim does not evaluate alarm expressions.
+func alarmFilterMatch(filter types.AlarmFilterSpec, e mo.Entity, state types.AlarmState) bool {
+	if len(filter.Status) != 0 {
+		match := false
+		for _, status := range filter.Status {
+			if status == state.OverallStatus {
+				match = true
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+
+	switch types.AlarmFilterSpecAlarmTypeByEntity(filter.TypeEntity) {
+	case types.AlarmFilterSpecAlarmTypeByEntityEntityTypeHost:
+		return e.Reference().Type == "HostSystem"
+	case types.AlarmFilterSpecAlarmTypeByEntityEntityTypeVm:
+		return e.Reference().Type == "VirtualMachine"
+	}
+
+	return true
+}
+
+func (m *AlarmManager) ClearTriggeredAlarms(ctx *Context, req *types.ClearTriggeredAlarms) soap.HasFault {
+	for _, e := range Map.All("") {
+		// Entities without triggered alarms are skipped without taking their lock, the check is repeated under lock.
+		if len(e.Entity().TriggeredAlarmState) == 0 {
+			continue
+		}
+
+		ctx.WithLock(e, func() {
+			var states []types.AlarmState
+			for _, state := range e.Entity().TriggeredAlarmState {
+				if !alarmFilterMatch(req.Filter, e, state) {
+					states = append(states, state)
+				}
+			}
+
+			if len(states) != len(e.Entity().TriggeredAlarmState) {
+				ctx.Map.Update(e, []types.PropertyChange{{Name: "triggeredAlarmState", Val: states}})
+			}
+		})
+	}
+
+	return &methods.ClearTriggeredAlarmsBody{
+		Res: new(types.ClearTriggeredAlarmsResponse),
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAlarmManager(t *testing.T) {
+	ctx := context.Background()
+
+	m := VPX()
+
+	err := m.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Remove()
+
+	c := m.Service.client
+	manager := *c.ServiceContent.AlarmManager
+	pc := property.DefaultCollector(c)
+
+	vm := Map.Any("VirtualMachine").(*VirtualMachine)
+	alarm := &Alarm{}
+	Map.Put(alarm)
+
+	// vcsim does not trigger alarms, simulate a triggered alarm
+	vm.TriggeredAlarmState = []types.AlarmState{{
+		Key:           alarm.Self.Value + "." + vm.Self.Value,
+		Entity:        vm.Self,
+		Alarm:         alarm.Self,
+		OverallStatus: types.ManagedEntityStatusRed,
+	}}
+
+	// wait calls f with the triggeredAlarmState updates that follow the current value
+	wait := func(f func([]types.AlarmState) bool) chan error {
+		ready, done := make(chan struct{}), make(chan error, 1)
+		go func() {
+			first := true
+			done <- property.Wait(ctx, pc, vm.Self, []string{"triggeredAlarmState"}, func(changes []types.PropertyChange) bool {
+				if first {
+					first = false
+					close(ready)
+					return false
+				}
+				for _, change := range changes {
+					var states []types.AlarmState
+					switch val := change.Val.(type) {
+					case types.ArrayOfAlarmState:
+						states = val.AlarmState
+					case *types.ArrayOfAlarmState:
+						states = val.AlarmState
+					}
+					if f(states) {
+						return true
+					}
+				}
+				return false
+			})
+		}()
+		<-ready
+		return done
+	}
+
+	acknowledged := wait(func(states []types.AlarmState) bool {
+		return len(states) == 1 && states[0].Acknowledged != nil && *states[0].Acknowledged
+	})
+
+	_, err = methods.AcknowledgeAlarm(ctx, c, &types.AcknowledgeAlarm{This: manager, Alarm: alarm.Self, Entity: vm.Self})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = <-acknowledged; err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := methods.GetAlarmState(ctx, c, &types.GetAlarmState{This: manager, Entity: vm.Self})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Returnval) != 1 || res.Returnval[0].AcknowledgedTime == nil {
+		t.Errorf("states=%#v", res.Returnval)
+	}
+
+	// alarm does not exist
+	enoent := types.ManagedObjectReference{Type: "Alarm", Value: "enoent"}
+	_, err = methods.AcknowledgeAlarm(ctx, c, &types.AcknowledgeAlarm{This: manager, Alarm: enoent, Entity: vm.Self})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if _, ok := soap.ToSoapFault(err).VimFault().(*types.ManagedObjectNotFound); !ok {
+		t.Errorf("fault=%#v", soap.ToSoapFault(err).VimFault())
+	}
+
+	// alarm is not triggered on the entity
+	host := Map.Any("HostSystem").Reference()
+	_, err = methods.AcknowledgeAlarm(ctx, c, &types.AcknowledgeAlarm{This: manager, Alarm: alarm.Self, Entity: host})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if _, ok := soap.ToSoapFault(err).VimFault().(*types.InvalidArgument); !ok {
+		t.Errorf("fault=%#v", soap.ToSoapFault(err).VimFault())
+	}
+
+	cleared := wait(func(states []types.AlarmState) bool {
+		return len(states) == 0
+	})
+
+	_, err = methods.ClearTriggeredAlarms(ctx, c, &types.ClearTriggeredAlarms{
+		This: manager,
+		Filter: types.AlarmFilterSpec{
+			Status:     []types.ManagedEntityStatus{types.ManagedEntityStatusRed},
+			TypeEntity: string(types.AlarmFilterSpecAlarmTypeByEntityEntityTypeVm),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = <-cleared; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -111,6 +111,14 @@ func TestCustomFieldsManager(t *testing.T) {
 		t.Fatalf("expect value.Value to be %q; got %q", "value", value)
 	}
 
+	named, err := fieldsManager.Values(ctx, vm.Reference())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if named["new_field_name"] != "value" {
+		t.Fatalf("expect Values()[%q] to be %q; got %v", "new_field_name", "value", named)
+	}
+
 	err = fieldsManager.Remove(ctx, field.Key)
 	if err != nil {
 		t.Fatal(err)
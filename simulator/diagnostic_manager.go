@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// maxLogLines is the max number of lines returned by BrowseDiagnosticLog, as with ESX.
+const maxLogLines = 1000
+
+// DiagnosticManager implements log browsing, where the hostd and vpxd logs contain
+// a line for each event posted to the EventManager.
+type DiagnosticManager struct {
+	mo.DiagnosticManager
+
+	offset int32 // line number of lines[0], incremented as lines are pruned
+	lines  []string
+}
+
+var (
+	hostdLog = types.DiagnosticManagerLogDescriptor{
+		Key:      "hostd",
+		FileName: "/var/log/hostd.log",
+		Creator:  string(types.DiagnosticManagerLogCreatorHostd),
+		Format:   string(types.DiagnosticManagerLogFormatPlain),
+		MimeType: "text/plain",
+		Info:     &types.Description{Label: "Hostd log", Summary: "Server log in 'plain' format"},
+	}
+
+	vpxdLog = types.DiagnosticManagerLogDescriptor{
+		Key:      "vpxd:vpxd.log",
+		FileName: "/var/log/vmware/vpxd/vpxd.log",
+		Creator:  string(types.DiagnosticManagerLogCreatorVpxd),
+		Format:   string(types.DiagnosticManagerLogFormatPlain),
+		MimeType: "text/plain",
+		Info:     &types.Description{Label: "vpxd.log", Summary: "vCenter server log in 'plain' format"},
+	}
+)
+
+// logEvent appends a line to the log for the given event
+func (m *DiagnosticManager) logEvent(event types.BaseEvent) {
+	e := event.GetEvent()
+	line := fmt.Sprintf("%s info vcsim[%s] Event %d : %s",
+		e.CreatedTime.UTC().Format(time.RFC3339Nano), reflect.TypeOf(event).Elem().Name(), e.Key, e.FullFormattedMessage)
+
+	if len(m.lines) > maxPageSize*5 {
+		m.lines = m.lines[1:] // Prune history
+		m.offset++
+	}
+
+	m.lines = append(m.lines, line)
+}
+
+func (m *DiagnosticManager) descriptor(host *types.ManagedObjectReference) types.DiagnosticManagerLogDescriptor {
+	if host == nil && Map.IsVPX() {
+		return vpxdLog
+	}
+	return hostdLog
+}
+
+func (m *DiagnosticManager) QueryDescriptions(req *types.QueryDescriptions) soap.HasFault {
+	return &methods.QueryDescriptionsBody{
+		Res: &types.QueryDescriptionsResponse{
+			Returnval: []types.DiagnosticManagerLogDescriptor{m.descriptor(req.Host)},
+		},
+	}
+}
+
+func (m *DiagnosticManager) BrowseDiagnosticLog(req *types.BrowseDiagnosticLog) soap.HasFault {
+	body := new(methods.BrowseDiagnosticLogBody)
+
+	if req.Host != nil && Map.Get(*req.Host) == nil {
+		body.Fault_ = Fault("", &types.ManagedObjectNotFound{Obj: *req.Host})
+		return body
+	}
+
+	if req.Key != m.descriptor(req.Host).Key {
+		body.Fault_ = Fault("", &types.CannotAccessFile{FileFault: types.FileFault{File: req.Key}})
+		return body
+	}
+
+	end := m.offset + int32(len(m.lines))
+
+	start := req.Start
+	if start < m.offset {
+		start = m.offset
+	}
+	if start > end {
+		start = end
+	}
+
+	n := req.Lines
+	if n <= 0 || n > maxLogLines {
+		n = maxLogLines
+	}
+	if n > end-start {
+		n = end - start
+	}
+
+	body.Res = &types.BrowseDiagnosticLogResponse{
+		Returnval: types.DiagnosticManagerLogHeader{
+			LineStart: start,
+			LineEnd:   end,
+			LineText:  append([]string(nil), m.lines[start-m.offset:start-m.offset+n]...),
+		},
+	}
+
+	return body
+}
@@ -167,6 +167,12 @@ func (m *EventManager) PostEvent(ctx *Context, req *types.PostEvent) soap.HasFau
 
 	pushEvent(m.history, req.EventToPost)
 
+	if ref := Map.content().DiagnosticManager; ref != nil {
+		if dm, ok := Map.Get(*ref).(*DiagnosticManager); ok {
+			ctx.WithLock(dm, func() { dm.logEvent(req.EventToPost) })
+		}
+	}
+
 	for _, c := range m.collectors {
 		ctx.WithLock(c, func() {
 			if c.eventMatches(req.EventToPost) {
@@ -410,7 +416,7 @@ func (c *EventHistoryCollector) ResetCollector(ctx *Context, req *types.ResetCol
 }
 
 func (c *EventHistoryCollector) RewindCollector(ctx *Context, req *types.RewindCollector) soap.HasFault {
-	c.pos = c.page.Front()
+	c.pos = nil // ReadNextEvents starts with the oldest event
 
 	return &methods.RewindCollectorBody{
 		Res: new(types.RewindCollectorResponse),
@@ -227,12 +227,14 @@ func (*Model) fmtName(prefix string, num int) string {
 
 // kinds maps managed object types to their vcsim wrapper types
 var kinds = map[string]reflect.Type{
+	"AlarmManager":                    reflect.TypeOf((*AlarmManager)(nil)).Elem(),
 	"AuthorizationManager":            reflect.TypeOf((*AuthorizationManager)(nil)).Elem(),
 	"ClusterComputeResource":          reflect.TypeOf((*ClusterComputeResource)(nil)).Elem(),
 	"CustomFieldsManager":             reflect.TypeOf((*CustomFieldsManager)(nil)).Elem(),
 	"CustomizationSpecManager":        reflect.TypeOf((*CustomizationSpecManager)(nil)).Elem(),
 	"Datacenter":                      reflect.TypeOf((*Datacenter)(nil)).Elem(),
 	"Datastore":                       reflect.TypeOf((*Datastore)(nil)).Elem(),
+	"DiagnosticManager":               reflect.TypeOf((*DiagnosticManager)(nil)).Elem(),
 	"DistributedVirtualPortgroup":     reflect.TypeOf((*DistributedVirtualPortgroup)(nil)).Elem(),
 	"DistributedVirtualSwitch":        reflect.TypeOf((*DistributedVirtualSwitch)(nil)).Elem(),
 	"DistributedVirtualSwitchManager": reflect.TypeOf((*DistributedVirtualSwitchManager)(nil)).Elem(),
@@ -252,6 +254,7 @@ var kinds = map[string]reflect.Type{
 	"PerformanceManager":              reflect.TypeOf((*PerformanceManager)(nil)).Elem(),
 	"PropertyCollector":               reflect.TypeOf((*PropertyCollector)(nil)).Elem(),
 	"ResourcePool":                    reflect.TypeOf((*ResourcePool)(nil)).Elem(),
+	"ScheduledTaskManager":            reflect.TypeOf((*ScheduledTaskManager)(nil)).Elem(),
 	"SearchIndex":                     reflect.TypeOf((*SearchIndex)(nil)).Elem(),
 	"SessionManager":                  reflect.TypeOf((*SessionManager)(nil)).Elem(),
 	"StoragePod":                      reflect.TypeOf((*StoragePod)(nil)).Elem(),
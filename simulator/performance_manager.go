@@ -179,74 +179,107 @@ func (p *PerformanceManager) QueryPerf(ctx *Context, req *types.QueryPerf) soap.
 	body.Res.Returnval = make([]types.BasePerfEntityMetricBase, len(req.QuerySpec))
 
 	for i, qs := range req.QuerySpec {
-		metrics := new(types.PerfEntityMetric)
-		metrics.Entity = qs.Entity
-
 		// Get metric data for this entity type
-		metricData, ok := p.metricData[qs.Entity.Type]
-		if !ok {
+		if _, ok := p.metricData[qs.Entity.Type]; !ok {
 			body.Fault_ = Fault("", &types.InvalidArgument{
 				InvalidProperty: "Entity",
 			})
 		}
-		var start, end time.Time
-		if qs.StartTime == nil {
-			start = time.Now().Add(time.Duration(-365*24) * time.Hour) // Assume we have data for a year
-		} else {
-			start = *qs.StartTime
-		}
-		if qs.EndTime == nil {
-			end = time.Now()
-		} else {
-			end = *qs.EndTime
-		}
 
-		// Generate metric series. Divide into n buckets of interval seconds
-		interval := qs.IntervalId
-		if interval == -1 || interval == 0 {
-			interval = 20 // TODO: Determine from entity type
-		}
-		n := 1 + int32(end.Sub(start).Seconds())/interval
-		if n > qs.MaxSample {
-			n = qs.MaxSample
-		}
+		body.Res.Returnval[i] = p.queryPerf(qs)
+	}
+	return body
+}
+
+func (p *PerformanceManager) QueryPerfComposite(ctx *Context, req *types.QueryPerfComposite) soap.HasFault {
+	body := new(methods.QueryPerfCompositeBody)
+
+	qs := req.QuerySpec
+	host, ok := ctx.Map.Get(qs.Entity).(*HostSystem)
+	if !ok {
+		body.Fault_ = Fault("", &types.InvalidArgument{
+			InvalidProperty: "querySpec.entity",
+		})
+		return body
+	}
+
+	body.Res = &types.QueryPerfCompositeResponse{
+		Returnval: types.PerfCompositeMetric{
+			Entity: p.queryPerf(qs),
+		},
+	}
+
+	for _, vm := range host.Vm {
+		qs.Entity = vm
+		body.Res.Returnval.ChildEntity = append(body.Res.Returnval.ChildEntity, p.queryPerf(qs))
+	}
+
+	return body
+}
+
+// queryPerf generates the metric series for the given spec
+func (p *PerformanceManager) queryPerf(qs types.PerfQuerySpec) *types.PerfEntityMetric {
+	metrics := new(types.PerfEntityMetric)
+	metrics.Entity = qs.Entity
+
+	metricData := p.metricData[qs.Entity.Type]
+
+	var start, end time.Time
+	if qs.StartTime == nil {
+		start = time.Now().Add(time.Duration(-365*24) * time.Hour) // Assume we have data for a year
+	} else {
+		start = *qs.StartTime
+	}
+	if qs.EndTime == nil {
+		end = time.Now()
+	} else {
+		end = *qs.EndTime
+	}
+
+	// Generate metric series. Divide into n buckets of interval seconds
+	interval := qs.IntervalId
+	if interval == -1 || interval == 0 {
+		interval = 20 // TODO: Determine from entity type
+	}
+	n := 1 + int32(end.Sub(start).Seconds())/interval
+	if n > qs.MaxSample {
+		n = qs.MaxSample
+	}
+
+	// Loop through each interval "tick"
+	metrics.SampleInfo = make([]types.PerfSampleInfo, n)
+	metrics.Value = make([]types.BasePerfMetricSeries, len(qs.MetricId))
+	for tick := int32(0); tick < n; tick++ {
+		metrics.SampleInfo[tick] = types.PerfSampleInfo{Timestamp: end.Add(time.Duration(-interval*tick) * time.Second), Interval: interval}
+	}
+
+	for j, mid := range qs.MetricId {
+		// Create list of metrics for this tick
+		series := &types.PerfMetricIntSeries{Value: make([]int64, n)}
+		series.Id = mid
+		points := metricData[mid.CounterId]
+		offset := int64(start.Unix()) / int64(interval)
 
-		// Loop through each interval "tick"
-		metrics.SampleInfo = make([]types.PerfSampleInfo, n)
-		metrics.Value = make([]types.BasePerfMetricSeries, len(qs.MetricId))
 		for tick := int32(0); tick < n; tick++ {
-			metrics.SampleInfo[tick] = types.PerfSampleInfo{Timestamp: end.Add(time.Duration(-interval*tick) * time.Second), Interval: interval}
-		}
+			var p int64
 
-		for j, mid := range qs.MetricId {
-			// Create list of metrics for this tick
-			series := &types.PerfMetricIntSeries{Value: make([]int64, n)}
-			series.Id = mid
-			points := metricData[mid.CounterId]
-			offset := int64(start.Unix()) / int64(interval)
-
-			for tick := int32(0); tick < n; tick++ {
-				var p int64
-
-				// Use sample data if we have it. Otherwise, just send 0.
-				if len(points) > 0 {
-					p = points[(offset+int64(tick))%int64(len(points))]
-					scale := p / 5
-					if scale > 0 {
-						// Add some gaussian noise to make the data look more "real"
-						p += int64(rand.NormFloat64() * float64(scale))
-						if p < 0 {
-							p = 0
-						}
+			// Use sample data if we have it. Otherwise, just send 0.
+			if len(points) > 0 {
+				p = points[(offset+int64(tick))%int64(len(points))]
+				scale := p / 5
+				if scale > 0 {
+					// Add some gaussian noise to make the data look more "real"
+					p += int64(rand.NormFloat64() * float64(scale))
+					if p < 0 {
+						p = 0
 					}
-				} else {
-					p = 0
 				}
-				series.Value[tick] = p
+			} else {
+				p = 0
 			}
-			metrics.Value[j] = series
+			series.Value[tick] = p
 		}
-		body.Res.Returnval[i] = metrics
+		metrics.Value[j] = series
 	}
-	return body
+	return metrics
 }
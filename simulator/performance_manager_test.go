@@ -331,3 +331,58 @@ func TestQueryPerf(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestQueryPerfComposite(t *testing.T) {
+	ctx := context.Background()
+
+	m := VPX()
+
+	err := m.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Remove()
+
+	p := performance.NewManager(m.Service.client)
+
+	vm := Map.Any("VirtualMachine").(*VirtualMachine)
+	host := Map.Get(*vm.Runtime.Host).(*HostSystem)
+
+	spec := types.PerfQuerySpec{
+		MaxSample:  4,
+		IntervalId: 20,
+		MetricId:   []types.PerfMetricId{{CounterId: 1}},
+		Entity:     host.Reference(),
+	}
+
+	res, err := p.QueryComposite(ctx, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ref := res.Entity.GetPerfEntityMetricBase().Entity; ref != host.Reference() {
+		t.Errorf("entity=%s", ref)
+	}
+
+	if len(res.ChildEntity) != len(host.Vm) {
+		t.Fatalf("%d child entities, expected %d", len(res.ChildEntity), len(host.Vm))
+	}
+
+	for i, child := range res.ChildEntity {
+		metric := child.(*types.PerfEntityMetric)
+		if metric.Entity != host.Vm[i] {
+			t.Errorf("child entity=%s, expected %s", metric.Entity, host.Vm[i])
+		}
+		if len(metric.SampleInfo) != 4 || len(metric.Value) != 1 {
+			t.Errorf("%s: %d samples, %d values", metric.Entity, len(metric.SampleInfo), len(metric.Value))
+		}
+	}
+
+	// entity must be a HostSystem
+	spec.Entity = host.Vm[0]
+	_, err = p.QueryComposite(ctx, spec)
+	if err == nil {
+		t.Error("expected error")
+	}
+}
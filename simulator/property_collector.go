@@ -26,6 +26,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/simulator/internal"
 	"github.com/vmware/govmomi/vim25"
@@ -42,6 +44,13 @@ type PropertyCollector struct {
 	updates []types.ObjectUpdate
 	mu      sync.Mutex
 	cancel  context.CancelFunc
+	pages   map[string]*retrievePages
+}
+
+// retrievePages holds the remaining objects of a RetrievePropertiesEx call limited by RetrieveOptions.MaxObjects
+type retrievePages struct {
+	objects []types.ObjectContent
+	max     int
 }
 
 func NewPropertyCollector(ref types.ManagedObjectReference) object.Reference {
@@ -519,6 +528,7 @@ func (pc *PropertyCollector) RetrievePropertiesEx(ctx *Context, r *types.Retriev
 			objects = append(objects, o)
 		}
 		res.Objects = objects
+		pc.page(res, &retrievePages{objects: objects, max: int(r.Options.MaxObjects)})
 		body.Res = &types.RetrievePropertiesExResponse{
 			Returnval: res,
 		}
@@ -527,6 +537,60 @@ func (pc *PropertyCollector) RetrievePropertiesEx(ctx *Context, r *types.Retriev
 	return body
 }
 
+// page limits res to the first p.max objects, saving the remaining objects for ContinueRetrievePropertiesEx.
+func (pc *PropertyCollector) page(res *types.RetrieveResult, p *retrievePages) {
+	res.Token = ""
+	res.Objects = p.objects
+
+	if p.max <= 0 || len(p.objects) <= p.max {
+		return
+	}
+
+	res.Objects = p.objects[:p.max]
+	p.objects = p.objects[p.max:]
+	res.Token = uuid.New().String()
+
+	pc.mu.Lock()
+	if pc.pages == nil {
+		pc.pages = make(map[string]*retrievePages)
+	}
+	pc.pages[res.Token] = p
+	pc.mu.Unlock()
+}
+
+func (pc *PropertyCollector) ContinueRetrievePropertiesEx(ctx *Context, r *types.ContinueRetrievePropertiesEx) soap.HasFault {
+	body := &methods.ContinueRetrievePropertiesExBody{}
+
+	pc.mu.Lock()
+	p, ok := pc.pages[r.Token]
+	delete(pc.pages, r.Token)
+	pc.mu.Unlock()
+
+	if !ok {
+		body.Fault_ = Fault("", &types.InvalidArgument{InvalidProperty: "token"})
+		return body
+	}
+
+	res := new(types.RetrieveResult)
+	pc.page(res, p)
+
+	body.Res = &types.ContinueRetrievePropertiesExResponse{
+		Returnval: *res,
+	}
+
+	return body
+}
+
+func (pc *PropertyCollector) CancelRetrievePropertiesEx(ctx *Context, r *types.CancelRetrievePropertiesEx) soap.HasFault {
+	pc.mu.Lock()
+	delete(pc.pages, r.Token)
+	pc.mu.Unlock()
+
+	return &methods.CancelRetrievePropertiesExBody{
+		Res: new(types.CancelRetrievePropertiesExResponse),
+	}
+}
+
 // RetrieveProperties is deprecated, but govmomi is still using it at the moment.
 func (pc *PropertyCollector) RetrieveProperties(ctx *Context, r *types.RetrieveProperties) soap.HasFault {
 	body := &methods.RetrievePropertiesBody{}
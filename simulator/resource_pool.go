@@ -480,3 +480,78 @@ func (p *ResourcePool) DestroyChildren(ctx *Context, req *types.DestroyChildren)
 
 	return &methods.DestroyChildrenBody{Res: new(types.DestroyChildrenResponse)}
 }
+
+// isAncestor returns true if ref is p or one of p's parent pools
+func (p *ResourcePool) isAncestor(ctx *Context, ref types.ManagedObjectReference) bool {
+	for self := &p.Self; self != nil; {
+		if *self == ref {
+			return true
+		}
+		pool, ok := asResourcePoolMO(ctx.Map.Get(*self))
+		if !ok {
+			return false
+		}
+		self = pool.Parent
+	}
+	return false
+}
+
+func (p *ResourcePool) MoveIntoResourcePool(ctx *Context, req *types.MoveIntoResourcePool) soap.HasFault {
+	body := &methods.MoveIntoResourcePoolBody{}
+
+	for _, ref := range req.List {
+		obj := ctx.Map.Get(ref)
+		if obj == nil {
+			body.Fault_ = Fault("", &types.ManagedObjectNotFound{Obj: ref})
+			return body
+		}
+
+		switch obj := obj.(type) {
+		case *ResourcePool:
+			if obj.Owner != p.Owner || p.isAncestor(ctx, ref) {
+				body.Fault_ = Fault("", &types.InvalidArgument{InvalidProperty: "list"})
+				return body
+			}
+			if e := ctx.Map.FindByName(obj.Name, p.ResourcePool.ResourcePool); e != nil && e.Reference() != ref {
+				body.Fault_ = Fault("", &types.DuplicateName{Name: obj.Name, Object: e.Reference()})
+				return body
+			}
+
+			parent, _ := asResourcePoolMO(ctx.Map.Get(*obj.Parent))
+			ctx.WithLock(parent, func() {
+				RemoveReference(&parent.ResourcePool, ref)
+				ctx.Map.Update(parent, []types.PropertyChange{{Name: "resourcePool", Val: parent.ResourcePool}})
+			})
+			ctx.Map.AtomicUpdate(ctx, obj, []types.PropertyChange{{Name: "parent", Val: &p.Self}})
+			ctx.WithLock(p, func() {
+				pool := append(p.ResourcePool.ResourcePool, ref)
+				ctx.Map.Update(p, []types.PropertyChange{{Name: "resourcePool", Val: pool}})
+			})
+		case *VirtualMachine:
+			if obj.ResourcePool == nil {
+				body.Fault_ = Fault("", &types.InvalidArgument{InvalidProperty: "list"}) // template
+				return body
+			}
+			parent, ok := asResourcePoolMO(ctx.Map.Get(*obj.ResourcePool))
+			if !ok {
+				body.Fault_ = Fault("", &types.NotSupported{}) // vApp member
+				return body
+			}
+			ctx.WithLock(parent, func() {
+				RemoveReference(&parent.Vm, ref)
+				ctx.Map.Update(parent, []types.PropertyChange{{Name: "vm", Val: parent.Vm}})
+			})
+			ctx.Map.AtomicUpdate(ctx, obj, []types.PropertyChange{{Name: "resourcePool", Val: &p.Self}})
+			ctx.WithLock(p, func() {
+				ctx.Map.Update(p, []types.PropertyChange{{Name: "vm", Val: append(p.Vm, ref)}})
+			})
+		default:
+			body.Fault_ = Fault("", &types.NotSupported{})
+			return body
+		}
+	}
+
+	body.Res = new(types.MoveIntoResourcePoolResponse)
+
+	return body
+}
@@ -308,6 +308,165 @@ func TestCreateVAppVPX(t *testing.T) {
 	}
 }
 
+func TestResourcePoolMoveInto(t *testing.T) {
+	ctx := context.Background()
+
+	m := VPX()
+
+	err := m.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Remove()
+
+	c := m.Service.client
+
+	vm := Map.Any("VirtualMachine").(*VirtualMachine)
+	root := Map.Get(*vm.ResourcePool).(*ResourcePool)
+	parent := object.NewResourcePool(c, root.Self)
+	spec := types.DefaultResourceConfigSpec()
+
+	a, err := parent.Create(ctx, "a", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := parent.Create(ctx, "b", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// property collector waiters see the move
+	pc := property.DefaultCollector(c)
+	wait := func(obj types.ManagedObjectReference, name string, match func(interface{}) bool) chan error {
+		ready, done := make(chan struct{}), make(chan error, 1)
+		go func() {
+			first := true
+			done <- property.Wait(ctx, pc, obj, []string{name}, func(changes []types.PropertyChange) bool {
+				if first {
+					first = false
+					close(ready)
+					return false
+				}
+				for _, change := range changes {
+					if change.Name == name && match(change.Val) {
+						return true
+					}
+				}
+				return false
+			})
+		}()
+		<-ready
+		return done
+	}
+	ref := func(ref types.ManagedObjectReference) func(interface{}) bool {
+		return func(val interface{}) bool {
+			switch val := val.(type) {
+			case types.ManagedObjectReference:
+				return val == ref
+			case *types.ManagedObjectReference:
+				return *val == ref
+			}
+			return false
+		}
+	}
+	list := func(ref types.ManagedObjectReference, found bool) func(interface{}) bool {
+		return func(val interface{}) bool {
+			var refs []types.ManagedObjectReference
+			switch val := val.(type) {
+			case types.ArrayOfManagedObjectReference:
+				refs = val.ManagedObjectReference
+			case *types.ArrayOfManagedObjectReference:
+				refs = val.ManagedObjectReference
+			}
+			return (FindReference(refs, ref) != nil) == found
+		}
+	}
+
+	waits := []chan error{
+		wait(vm.Self, "resourcePool", ref(a.Reference())),
+		wait(b.Reference(), "parent", ref(a.Reference())),
+		wait(a.Reference(), "vm", list(vm.Self, true)),
+		wait(a.Reference(), "resourcePool", list(b.Reference(), true)),
+		wait(root.Self, "vm", list(vm.Self, false)),
+		wait(root.Self, "resourcePool", list(b.Reference(), false)),
+	}
+
+	// move a VM and a pool into a
+	err = a.MoveInto(ctx, []types.ManagedObjectReference{vm.Self, b.Reference()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, done := range waits {
+		if err = <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pool := Map.Get(a.Reference()).(*ResourcePool)
+	if FindReference(pool.Vm, vm.Self) == nil || *vm.ResourcePool != pool.Self {
+		t.Errorf("vm not moved into %s", pool.Self)
+	}
+	if FindReference(root.Vm, vm.Self) != nil {
+		t.Errorf("vm still in %s", root.Self)
+	}
+	if FindReference(pool.ResourcePool.ResourcePool, b.Reference()) == nil {
+		t.Errorf("pool not moved into %s", pool.Self)
+	}
+	if FindReference(root.ResourcePool.ResourcePool, b.Reference()) != nil {
+		t.Errorf("pool still in %s", root.Self)
+	}
+	if *Map.Get(b.Reference()).(*ResourcePool).Parent != pool.Self {
+		t.Error("pool parent not updated")
+	}
+
+	// a pool can't be moved into itself or one of its children
+	for _, dst := range []*object.ResourcePool{a, b} {
+		err = dst.MoveInto(ctx, []types.ManagedObjectReference{a.Reference()})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		fault := soap.ToSoapFault(err).VimFault()
+		if _, ok := fault.(*types.InvalidArgument); !ok {
+			t.Errorf("fault=%#v", fault)
+		}
+	}
+
+	// a template has no resource pool
+	tmpl := object.NewVirtualMachine(c, Map.Any("VirtualMachine").Reference())
+	task, err := tmpl.PowerOff(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = task.Wait(ctx) // may already be powered off
+	if err = tmpl.MarkAsTemplate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	err = a.MoveInto(ctx, []types.ManagedObjectReference{tmpl.Reference()})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if _, ok := soap.ToSoapFault(err).VimFault().(*types.InvalidArgument); !ok {
+		t.Errorf("fault=%#v", soap.ToSoapFault(err).VimFault())
+	}
+
+	// duplicate name
+	dup, err := parent.Create(ctx, "b", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = a.MoveInto(ctx, []types.ManagedObjectReference{dup.Reference()})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	fault := soap.ToSoapFault(err).VimFault()
+	if _, ok := fault.(*types.DuplicateName); !ok {
+		t.Errorf("fault=%#v", fault)
+	}
+}
+
 func TestResourcePoolValidation(t *testing.T) {
 	tests := []func() bool{
 		func() bool {
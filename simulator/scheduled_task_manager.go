@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"time"
+
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// ScheduledTaskManager implements scheduled task definitions, the scheduled tasks are not run by vcsim.
+type ScheduledTaskManager struct {
+	mo.ScheduledTaskManager
+}
+
+type ScheduledTask struct {
+	mo.ScheduledTask
+}
+
+func (m *ScheduledTaskManager) CreateScheduledTask(ctx *Context, req *types.CreateScheduledTask) soap.HasFault {
+	body := &methods.CreateScheduledTaskBody{}
+
+	if Map.Get(req.Entity) == nil {
+		body.Fault_ = Fault("", &types.ManagedObjectNotFound{Obj: req.Entity})
+		return body
+	}
+
+	spec := req.Spec.GetScheduledTaskSpec()
+
+	for _, ref := range m.tasks(ctx) {
+		if task := Map.Get(ref).(*ScheduledTask); task.Info.Name == spec.Name {
+			body.Fault_ = Fault("", &types.DuplicateName{Name: spec.Name, Object: ref})
+			return body
+		}
+	}
+
+	task := &ScheduledTask{}
+	Map.Put(task)
+	task.Info = types.ScheduledTaskInfo{
+		ScheduledTaskSpec: *spec,
+		ScheduledTask:     task.Self,
+		Entity:            req.Entity,
+		LastModifiedTime:  time.Now(),
+		LastModifiedUser:  ctx.Session.UserName,
+		State:             types.TaskInfoStateQueued,
+	}
+
+	ctx.WithLock(m, func() {
+		ctx.Map.Update(m, []types.PropertyChange{{Name: "scheduledTask", Val: append(m.ScheduledTask, task.Self)}})
+	})
+
+	body.Res = &types.CreateScheduledTaskResponse{
+		Returnval: task.Self,
+	}
+
+	return body
+}
+
+// tasks returns a copy of the manager's scheduled task references.
+func (m *ScheduledTaskManager) tasks(ctx *Context) []types.ManagedObjectReference {
+	var refs []types.ManagedObjectReference
+
+	ctx.WithLock(m, func() {
+		refs = append(refs, m.ScheduledTask...)
+	})
+
+	return refs
+}
+
+func (m *ScheduledTaskManager) RetrieveEntityScheduledTask(ctx *Context, req *types.RetrieveEntityScheduledTask) soap.HasFault {
+	var refs []types.ManagedObjectReference
+
+	for _, ref := range m.tasks(ctx) {
+		task := Map.Get(ref).(*ScheduledTask)
+		if req.Entity == nil || *req.Entity == task.Info.Entity {
+			refs = append(refs, ref)
+		}
+	}
+
+	return &methods.RetrieveEntityScheduledTaskBody{
+		Res: &types.RetrieveEntityScheduledTaskResponse{
+			Returnval: refs,
+		},
+	}
+}
+
+func (t *ScheduledTask) RemoveScheduledTask(ctx *Context, req *types.RemoveScheduledTask) soap.HasFault {
+	m := Map.Get(*Map.content().ScheduledTaskManager).(*ScheduledTaskManager)
+
+	ctx.WithLock(m, func() {
+		refs := append([]types.ManagedObjectReference(nil), m.ScheduledTask...)
+		RemoveReference(&refs, t.Self)
+		ctx.Map.Update(m, []types.PropertyChange{{Name: "scheduledTask", Val: refs}})
+	})
+
+	Map.Remove(ctx, t.Self)
+
+	return &methods.RemoveScheduledTaskBody{
+		Res: new(types.RemoveScheduledTaskResponse),
+	}
+}
@@ -368,3 +368,160 @@ func TestSessionManagerPropertyCollector(t *testing.T) {
 		t.Errorf("kind=%s", set.Kind)
 	}
 }
+
+func TestSessionManagerLogoutNotLoggedIn(t *testing.T) {
+	ctx := context.Background()
+
+	m := ESX()
+
+	defer m.Remove()
+
+	err := m.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := m.Service.NewServer()
+	defer s.Close()
+
+	u := s.URL.User
+	s.URL.User = nil // skip Login()
+
+	c, err := govmomi.NewClient(ctx, s.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.Logout(ctx)
+	if err != nil {
+		t.Fatalf("Logout without Login: %s", err)
+	}
+
+	err = c.Login(ctx, u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = c.Logout(ctx)
+		if err != nil {
+			t.Fatalf("Logout %d: %s", i, err)
+		}
+	}
+}
+
+func TestSessionManagerSessionList(t *testing.T) {
+	ctx := context.Background()
+
+	m := VPX()
+
+	defer m.Remove()
+
+	err := m.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := m.Service.NewServer()
+	defer s.Close()
+
+	c1, err := govmomi.NewClient(ctx, s.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c2, err := govmomi.NewClient(ctx, s.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := c1.SessionManager.UserSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := c2.SessionManager.UserSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := c1.SessionManager.SessionList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make(map[string]bool)
+	for _, session := range list {
+		keys[session.Key] = true
+	}
+
+	if !keys[current.Key] || !keys[other.Key] {
+		t.Fatalf("expected sessions %s and %s in %v", current.Key, other.Key, keys)
+	}
+
+	err = c1.SessionManager.TerminateSession(ctx, []string{current.Key})
+	if err == nil {
+		t.Error("expected error terminating current session")
+	}
+
+	err = c1.SessionManager.TerminateSessions(ctx, []string{current.Key}, true)
+	if err == nil {
+		t.Error("expected error terminating current session with force")
+	}
+
+	err = c1.SessionManager.TerminateSessions(ctx, []string{current.Key, other.Key}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = methods.GetCurrentTime(ctx, c2)
+	if !isNotAuthenticated(err) {
+		t.Errorf("expected NotAuthenticated, got %v", err)
+	}
+
+	_, err = methods.GetCurrentTime(ctx, c1)
+	if err != nil {
+		t.Errorf("current session was terminated: %s", err)
+	}
+}
+
+func TestSessionManagerLocale(t *testing.T) {
+	ctx := context.Background()
+
+	m := ESX()
+
+	defer m.Remove()
+
+	err := m.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := m.Service.NewServer()
+	defer s.Close()
+
+	u := s.URL.User
+	s.URL.User = nil // skip Login()
+
+	c, err := govmomi.NewClient(ctx, s.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sm := session.NewManager(c.Client)
+	sm.Locale = "de"
+
+	err = sm.Login(ctx, u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	us, err := sm.UserSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if us.Locale != "de" {
+		t.Errorf("locale=%q", us.Locale)
+	}
+}
@@ -664,6 +664,7 @@ func (s *Service) NewServer() *Server {
 	mux.HandleFunc(folderPrefix, s.ServeDatastore)
 	mux.HandleFunc(guestPrefix, ServeGuest)
 	mux.HandleFunc(nfcPrefix, ServeNFC)
+	mux.HandleFunc(screenPrefix, ServeScreen)
 	mux.HandleFunc("/about", s.About)
 
 	if s.Listen == nil {
@@ -17,11 +17,16 @@ limitations under the License.
 package simulator
 
 import (
+	"container/list"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/vmware/govmomi/simulator/esx"
 	"github.com/vmware/govmomi/simulator/vpx"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -62,3 +67,276 @@ func (m *TaskManager) PutObject(obj mo.Reference) {
 func (*TaskManager) RemoveObject(*Context, types.ManagedObjectReference) {}
 
 func (*TaskManager) UpdateObject(mo.Reference, []types.PropertyChange) {}
+
+func (m *TaskManager) CreateCollectorForTasks(ctx *Context, req *types.CreateCollectorForTasks) soap.HasFault {
+	collector := &TaskHistoryCollector{
+		page: list.New(),
+		size: 10, // defaultPageSize
+	}
+	collector.Filter = req.Filter
+	collector.fillPage(ctx)
+
+	return &methods.CreateCollectorForTasksBody{
+		Res: &types.CreateCollectorForTasksResponse{
+			Returnval: ctx.Session.Put(collector).Reference(),
+		},
+	}
+}
+
+// TaskHistoryCollector pages through the TaskInfo of the tasks matching its filter,
+// as of when the collector was created or its page size was last set.
+type TaskHistoryCollector struct {
+	mo.TaskHistoryCollector
+
+	size int
+	page *list.List
+	pos  *list.Element
+}
+
+// fillPage copies the info of the tasks matching Filter into the collector's page, oldest first.
+func (c *TaskHistoryCollector) fillPage(ctx *Context) {
+	var tasks []types.TaskInfo
+
+	for _, obj := range Map.AllReference("Task") {
+		task := obj.(*Task)
+		var info types.TaskInfo
+		ctx.WithLock(task, func() { info = task.Info })
+
+		if c.taskMatches(&info) {
+			tasks = append(tasks, info)
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].QueueTime.Before(tasks[j].QueueTime)
+	})
+
+	for _, info := range tasks {
+		c.page.PushBack(info)
+	}
+}
+
+// taskFilterChildren returns true if ref is a descendant of self.
+func taskFilterChildren(ref, self types.ManagedObjectReference) bool {
+	seen := false
+
+	var match func(types.ManagedObjectReference)
+
+	match = func(child types.ManagedObjectReference) {
+		if seen {
+			return
+		}
+		if child == ref {
+			seen = true
+			return
+		}
+		walk(Map.Get(child), match)
+	}
+
+	walk(Map.Get(self), match)
+
+	return seen
+}
+
+// taskMatches returns true if the task info matches all of the collector's filters.
+func (c *TaskHistoryCollector) taskMatches(info *types.TaskInfo) bool {
+	spec := c.Filter.(types.TaskFilterSpec)
+
+	if e := spec.Entity; e != nil {
+		if info.Entity == nil {
+			return false
+		}
+
+		self := *info.Entity == e.Entity
+
+		switch e.Recursion {
+		case types.TaskFilterSpecRecursionOptionSelf:
+			if !self {
+				return false
+			}
+		case types.TaskFilterSpecRecursionOptionChildren:
+			if !taskFilterChildren(*info.Entity, e.Entity) {
+				return false
+			}
+		default:
+			if !self && !taskFilterChildren(*info.Entity, e.Entity) {
+				return false
+			}
+		}
+	}
+
+	if len(spec.State) != 0 {
+		match := false
+		for _, state := range spec.State {
+			if state == info.State {
+				match = true
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+
+	if t := spec.Time; t != nil {
+		var when *time.Time
+		switch t.TimeType {
+		case types.TaskFilterSpecTimeOptionQueuedTime:
+			when = &info.QueueTime
+		case types.TaskFilterSpecTimeOptionStartedTime:
+			when = info.StartTime
+		case types.TaskFilterSpecTimeOptionCompletedTime:
+			when = info.CompleteTime
+		}
+		if when == nil {
+			return false
+		}
+		if t.BeginTime != nil && when.Before(*t.BeginTime) {
+			return false
+		}
+		if t.EndTime != nil && when.After(*t.EndTime) {
+			return false
+		}
+	}
+
+	if u := spec.UserName; u != nil {
+		switch reason := info.Reason.(type) {
+		case *types.TaskReasonUser:
+			match := false
+			for _, name := range u.UserList {
+				if name == reason.UserName {
+					match = true
+				}
+			}
+			if !match {
+				return false
+			}
+		case *types.TaskReasonSystem:
+			if !u.SystemUser {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (c *TaskHistoryCollector) SetCollectorPageSize(ctx *Context, req *types.SetCollectorPageSize) soap.HasFault {
+	body := new(methods.SetCollectorPageSizeBody)
+	size, err := validatePageSize(req.MaxCount)
+	if err != nil {
+		body.Fault_ = err
+		return body
+	}
+
+	c.size = size
+	c.page = list.New()
+	c.pos = nil
+	c.fillPage(ctx)
+
+	body.Res = new(types.SetCollectorPageSizeResponse)
+	return body
+}
+
+func (c *TaskHistoryCollector) ResetCollector(ctx *Context, req *types.ResetCollector) soap.HasFault {
+	c.pos = c.page.Back()
+
+	return &methods.ResetCollectorBody{
+		Res: new(types.ResetCollectorResponse),
+	}
+}
+
+func (c *TaskHistoryCollector) RewindCollector(ctx *Context, req *types.RewindCollector) soap.HasFault {
+	c.pos = nil // ReadNextTasks starts with the oldest task
+
+	return &methods.RewindCollectorBody{
+		Res: new(types.RewindCollectorResponse),
+	}
+}
+
+// readTasks returns the next max tasks from the collector's page
+func (c *TaskHistoryCollector) readTasks(max int32, next func() *list.Element) []types.TaskInfo {
+	var tasks []types.TaskInfo
+
+	for i := 0; i < int(max); i++ {
+		e := next()
+		if e == nil {
+			break
+		}
+
+		tasks = append(tasks, e.Value.(types.TaskInfo))
+		c.pos = e
+	}
+
+	return tasks
+}
+
+func (c *TaskHistoryCollector) ReadNextTasks(ctx *Context, req *types.ReadNextTasks) soap.HasFault {
+	body := &methods.ReadNextTasksBody{}
+	if req.MaxCount <= 0 {
+		body.Fault_ = Fault("", &types.InvalidArgument{InvalidProperty: "maxCount"})
+		return body
+	}
+	body.Res = new(types.ReadNextTasksResponse)
+
+	next := func() *list.Element {
+		if c.pos != nil {
+			return c.pos.Next()
+		}
+		return c.page.Front()
+	}
+
+	body.Res.Returnval = c.readTasks(req.MaxCount, next)
+
+	return body
+}
+
+func (c *TaskHistoryCollector) ReadPreviousTasks(ctx *Context, req *types.ReadPreviousTasks) soap.HasFault {
+	body := &methods.ReadPreviousTasksBody{}
+	if req.MaxCount <= 0 {
+		body.Fault_ = Fault("", &types.InvalidArgument{InvalidProperty: "maxCount"})
+		return body
+	}
+	body.Res = new(types.ReadPreviousTasksResponse)
+
+	next := func() *list.Element {
+		if c.pos != nil {
+			return c.pos.Prev()
+		}
+		return c.page.Back()
+	}
+
+	body.Res.Returnval = c.readTasks(req.MaxCount, next)
+
+	return body
+}
+
+func (c *TaskHistoryCollector) DestroyCollector(ctx *Context, req *types.DestroyCollector) soap.HasFault {
+	ctx.Session.Remove(ctx, req.This)
+
+	return &methods.DestroyCollectorBody{
+		Res: new(types.DestroyCollectorResponse),
+	}
+}
+
+func (c *TaskHistoryCollector) GetLatestPage() []types.TaskInfo {
+	var latestPage []types.TaskInfo
+
+	e := c.page.Back()
+	for i := 0; i < c.size; i++ {
+		if e == nil {
+			break
+		}
+		latestPage = append(latestPage, e.Value.(types.TaskInfo))
+		e = e.Prev()
+	}
+
+	return latestPage
+}
+
+func (c *TaskHistoryCollector) Get() mo.Reference {
+	clone := *c
+
+	clone.LatestPage = clone.GetLatestPage()
+
+	return &clone
+}
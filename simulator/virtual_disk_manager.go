@@ -188,6 +188,41 @@ func (m *VirtualDiskManager) CopyVirtualDiskTask(ctx *Context, req *types.CopyVi
 	}
 }
 
+func (m *VirtualDiskManager) ExtendVirtualDiskTask(ctx *Context, req *types.ExtendVirtualDisk_Task) soap.HasFault {
+	task := CreateTask(m, "extendVirtualDisk", func(*Task) (types.AnyType, types.BaseMethodFault) {
+		fm := Map.FileManager()
+
+		file, fault := fm.resolve(req.Datacenter, req.Name)
+		if fault != nil {
+			return nil, fault
+		}
+
+		// The size of the flat file is used as the disk capacity
+		name := vdmNames(file)[0]
+		info, err := os.Stat(name)
+		if err != nil {
+			return nil, fm.fault(req.Name, err, new(types.CannotAccessFile))
+		}
+
+		size := req.NewCapacityKb * 1024
+		if size < info.Size() {
+			return nil, &types.InvalidArgument{InvalidProperty: "newCapacityKb"}
+		}
+
+		if err = os.Truncate(name, size); err != nil {
+			return nil, fm.fault(req.Name, err, new(types.CannotAccessFile))
+		}
+
+		return nil, nil
+	})
+
+	return &methods.ExtendVirtualDisk_TaskBody{
+		Res: &types.ExtendVirtualDisk_TaskResponse{
+			Returnval: task.Run(ctx),
+		},
+	}
+}
+
 func virtualDiskUUID(dc *types.ManagedObjectReference, file string) string {
 	if dc != nil {
 		file = dc.String() + file
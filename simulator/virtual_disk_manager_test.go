@@ -18,6 +18,7 @@ package simulator
 
 import (
 	"context"
+	"os"
 	"path"
 	"strings"
 	"testing"
@@ -144,6 +145,51 @@ func TestVirtualDiskManager(t *testing.T) {
 		}
 	}
 
+	flat, fault := Map.FileManager().resolve(nil, strings.Replace(old, ".vmdk", "-flat.vmdk", 1))
+	if fault != nil {
+		t.Fatal(fault)
+	}
+
+	for _, fail := range []bool{false, true} {
+		// extend to 2GB, then fail to shrink to 1GB
+		capacity := spec.CapacityKb * 2
+		if fail {
+			capacity = spec.CapacityKb
+		}
+
+		task, err := dm.ExtendVirtualDisk(ctx, old, nil, capacity, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = task.Wait(ctx)
+		if fail {
+			if err == nil {
+				t.Error("expected error") // capacity can't shrink
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+
+		info, err := os.Stat(flat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() != spec.CapacityKb*2*1024 {
+			t.Errorf("size=%d", info.Size())
+		}
+	}
+
+	task, err := dm.ExtendVirtualDisk(ctx, old+"-enoent", nil, spec.CapacityKb, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = task.Wait(ctx); err == nil {
+		t.Error("expected error")
+	}
+
 	for _, fail := range []bool{false, true} {
 		task, err := dm.DeleteVirtualDisk(ctx, name, nil)
 		if err != nil {
@@ -19,9 +19,12 @@ package simulator
 import (
 	"bytes"
 	"fmt"
+	"image"
+	"image/png"
 	"io/ioutil"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -2265,3 +2268,23 @@ func changeTrackingSupported(spec *types.VirtualMachineConfigSpec) bool {
 	}
 	return false
 }
+
+const screenPrefix = "/screen"
+
+// ServeScreen handles VM console screenshot requests, responding with a blank PNG image
+func ServeScreen(w http.ResponseWriter, r *http.Request) {
+	ref := types.ManagedObjectReference{Type: "VirtualMachine", Value: r.URL.Query().Get("id")}
+	vm, ok := Map.Get(ref).(*VirtualMachine)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if vm.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOn {
+		http.Error(w, fmt.Sprintf("%s is %s", vm.Name, vm.Runtime.PowerState), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	_ = png.Encode(w, image.NewGray(image.Rect(0, 0, 640, 480)))
+}
@@ -18,9 +18,15 @@ package task
 
 import "github.com/vmware/govmomi/vim25/types"
 
+// Error is returned when a task fails.
+// The underlying fault can be inspected via the Fault method, for example with a type switch
+// or with predicates such as types.IsDuplicateName.
 type Error struct {
 	*types.LocalizedMethodFault
 	Description *types.LocalizableMessage
+
+	// Info is the TaskInfo of the failed task, if available.
+	Info *types.TaskInfo
 }
 
 // Error returns the task's localized fault message.
@@ -28,6 +34,7 @@ func (e Error) Error() string {
 	return e.LocalizedMethodFault.LocalizedMessage
 }
 
+// Fault returns the task's underlying fault.
 func (e Error) Fault() types.BaseMethodFault {
 	return e.LocalizedMethodFault.Fault
 }
@@ -0,0 +1,56 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package task
+
+import (
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestError(t *testing.T) {
+	info := &types.TaskInfo{
+		State: types.TaskInfoStateError,
+		Error: &types.LocalizedMethodFault{
+			Fault:            &types.DuplicateName{Name: "vm1"},
+			LocalizedMessage: "The name 'vm1' already exists.",
+		},
+	}
+
+	err := taskProgress{info}.Error()
+
+	terr, ok := err.(Error)
+	if !ok {
+		t.Fatalf("unexpected error type %T", err)
+	}
+
+	if terr.Info != info {
+		t.Error("expected TaskInfo")
+	}
+
+	if err.Error() != info.Error.LocalizedMessage {
+		t.Errorf("unexpected message %q", err)
+	}
+
+	if !types.IsDuplicateName(err) {
+		t.Error("expected DuplicateName")
+	}
+
+	if types.IsFileNotFound(err) {
+		t.Error("unexpected FileNotFound")
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package task
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// HistoryCollector wraps the TaskHistoryCollector managed object.
+type HistoryCollector struct {
+	r types.ManagedObjectReference
+	c *vim25.Client
+}
+
+// NewHistoryCollector creates a new task history collector
+func NewHistoryCollector(c *vim25.Client, ref types.ManagedObjectReference) *HistoryCollector {
+	return &HistoryCollector{
+		r: ref,
+		c: c,
+	}
+}
+
+// Reference returns the task.HistoryCollector MOID
+func (h HistoryCollector) Reference() types.ManagedObjectReference {
+	return h.r
+}
+
+func (h HistoryCollector) LatestPage(ctx context.Context) ([]types.TaskInfo, error) {
+	var o mo.TaskHistoryCollector
+
+	pc := property.DefaultCollector(h.c)
+	err := pc.RetrieveOne(ctx, h.Reference(), []string{"latestPage"}, &o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.LatestPage, nil
+}
+
+func (h HistoryCollector) ReadNextTasks(ctx context.Context, maxCount int32) ([]types.TaskInfo, error) {
+	req := types.ReadNextTasks{
+		This:     h.Reference(),
+		MaxCount: maxCount,
+	}
+
+	res, err := methods.ReadNextTasks(ctx, h.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+func (h HistoryCollector) ReadPreviousTasks(ctx context.Context, maxCount int32) ([]types.TaskInfo, error) {
+	req := types.ReadPreviousTasks{
+		This:     h.Reference(),
+		MaxCount: maxCount,
+	}
+
+	res, err := methods.ReadPreviousTasks(ctx, h.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
+
+func (h HistoryCollector) Destroy(ctx context.Context) error {
+	req := types.DestroyCollector{
+		This: h.Reference(),
+	}
+
+	_, err := methods.DestroyCollector(ctx, h.c, &req)
+	return err
+}
+
+func (h HistoryCollector) Reset(ctx context.Context) error {
+	req := types.ResetCollector{
+		This: h.Reference(),
+	}
+
+	_, err := methods.ResetCollector(ctx, h.c, &req)
+	return err
+}
+
+func (h HistoryCollector) Rewind(ctx context.Context) error {
+	req := types.RewindCollector{
+		This: h.Reference(),
+	}
+
+	_, err := methods.RewindCollector(ctx, h.c, &req)
+	return err
+}
+
+func (h HistoryCollector) SetPageSize(ctx context.Context, maxCount int32) error {
+	req := types.SetCollectorPageSize{
+		This:     h.Reference(),
+		MaxCount: maxCount,
+	}
+
+	_, err := methods.SetCollectorPageSize(ctx, h.c, &req)
+	return err
+}
@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Manager wraps the TaskManager managed object.
+type Manager struct {
+	r types.ManagedObjectReference
+	c *vim25.Client
+}
+
+// NewManager creates a new task manager
+func NewManager(c *vim25.Client) *Manager {
+	m := Manager{
+		r: *c.ServiceContent.TaskManager,
+		c: c,
+	}
+
+	return &m
+}
+
+// Reference returns the task.Manager MOID
+func (m Manager) Reference() types.ManagedObjectReference {
+	return m.r
+}
+
+// CreateCollectorForTasks returns a task history collector, a specialized
+// history collector that gathers TaskInfo data objects matching the given filter.
+func (m Manager) CreateCollectorForTasks(ctx context.Context, filter types.TaskFilterSpec) (*HistoryCollector, error) {
+	req := types.CreateCollectorForTasks{
+		This:   m.Reference(),
+		Filter: filter,
+	}
+
+	res, err := methods.CreateCollectorForTasks(ctx, m.c, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHistoryCollector(m.c, res.Returnval), nil
+}
+
+// DefaultPageSize is the number of tasks read per call by QueryTasks when pageSize is not positive.
+const DefaultPageSize = 100
+
+// QueryTasks returns all tasks matching the given filter, oldest first.
+// The tasks are read in pages of pageSize via a TaskHistoryCollector.
+// If pageSize is zero or negative, DefaultPageSize is used.
+func (m Manager) QueryTasks(ctx context.Context, filter types.TaskFilterSpec, pageSize int32) ([]types.TaskInfo, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	collector, err := m.CreateCollectorForTasks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = collector.Destroy(context.Background())
+	}()
+
+	err = collector.Rewind(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []types.TaskInfo
+
+	for {
+		page, err := collector.ReadNextTasks(ctx, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page) == 0 {
+			return tasks, nil
+		}
+
+		tasks = append(tasks, page...)
+	}
+}
+
+// Filter builds a TaskFilterSpec for use with CreateCollectorForTasks.
+type Filter struct {
+	types.TaskFilterSpec
+}
+
+// NewFilter returns an empty Filter, matching all tasks.
+func NewFilter() *Filter {
+	return new(Filter)
+}
+
+// ByEntity limits the filter to tasks on the given entity, including its children as specified by recursion.
+func (f *Filter) ByEntity(entity types.ManagedObjectReference, recursion types.TaskFilterSpecRecursionOption) *Filter {
+	f.Entity = &types.TaskFilterSpecByEntity{
+		Entity:    entity,
+		Recursion: recursion,
+	}
+	return f
+}
+
+// ByTime limits the filter to tasks with the given time property within begin and end.
+// A zero begin or end time leaves that end of the time window open.
+func (f *Filter) ByTime(kind types.TaskFilterSpecTimeOption, begin, end time.Time) *Filter {
+	f.Time = &types.TaskFilterSpecByTime{
+		TimeType: kind,
+	}
+	if !begin.IsZero() {
+		f.Time.BeginTime = types.NewTime(begin)
+	}
+	if !end.IsZero() {
+		f.Time.EndTime = types.NewTime(end)
+	}
+	return f
+}
+
+// ByState limits the filter to tasks in any of the given states.
+func (f *Filter) ByState(state ...types.TaskInfoState) *Filter {
+	f.State = append(f.State, state...)
+	return f
+}
+
+// ByUser limits the filter to tasks initiated by any of the given users.
+// If system is true, tasks initiated by the server are also included.
+func (f *Filter) ByUser(system bool, user ...string) *Filter {
+	f.UserName = &types.TaskFilterSpecByUsername{
+		SystemUser: system,
+		UserList:   user,
+	}
+	return f
+}
+
+// Spec returns the TaskFilterSpec built by the filter.
+func (f *Filter) Spec() types.TaskFilterSpec {
+	return f.TaskFilterSpec
+}
@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package task_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/task"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestFilter(t *testing.T) {
+	vm := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-42"}
+	begin := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := begin.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		filter *task.Filter
+		expect types.TaskFilterSpec
+	}{
+		{
+			"empty",
+			task.NewFilter(),
+			types.TaskFilterSpec{},
+		},
+		{
+			"entity",
+			task.NewFilter().ByEntity(vm, types.TaskFilterSpecRecursionOptionSelf),
+			types.TaskFilterSpec{
+				Entity: &types.TaskFilterSpecByEntity{Entity: vm, Recursion: types.TaskFilterSpecRecursionOptionSelf},
+			},
+		},
+		{
+			"time",
+			task.NewFilter().ByTime(types.TaskFilterSpecTimeOptionStartedTime, begin, end),
+			types.TaskFilterSpec{
+				Time: &types.TaskFilterSpecByTime{
+					TimeType:  types.TaskFilterSpecTimeOptionStartedTime,
+					BeginTime: &begin,
+					EndTime:   &end,
+				},
+			},
+		},
+		{
+			"time open end",
+			task.NewFilter().ByTime(types.TaskFilterSpecTimeOptionCompletedTime, begin, time.Time{}),
+			types.TaskFilterSpec{
+				Time: &types.TaskFilterSpecByTime{
+					TimeType:  types.TaskFilterSpecTimeOptionCompletedTime,
+					BeginTime: &begin,
+				},
+			},
+		},
+		{
+			"time open begin",
+			task.NewFilter().ByTime(types.TaskFilterSpecTimeOptionQueuedTime, time.Time{}, end),
+			types.TaskFilterSpec{
+				Time: &types.TaskFilterSpecByTime{
+					TimeType: types.TaskFilterSpecTimeOptionQueuedTime,
+					EndTime:  &end,
+				},
+			},
+		},
+		{
+			"state",
+			task.NewFilter().ByState(types.TaskInfoStateError).ByState(types.TaskInfoStateRunning, types.TaskInfoStateQueued),
+			types.TaskFilterSpec{
+				State: []types.TaskInfoState{types.TaskInfoStateError, types.TaskInfoStateRunning, types.TaskInfoStateQueued},
+			},
+		},
+		{
+			"user",
+			task.NewFilter().ByUser(true, "root", "admin"),
+			types.TaskFilterSpec{
+				UserName: &types.TaskFilterSpecByUsername{SystemUser: true, UserList: []string{"root", "admin"}},
+			},
+		},
+		{
+			"combined",
+			task.NewFilter().ByEntity(vm, types.TaskFilterSpecRecursionOptionAll).ByState(types.TaskInfoStateSuccess).ByUser(false, "root"),
+			types.TaskFilterSpec{
+				Entity:   &types.TaskFilterSpecByEntity{Entity: vm, Recursion: types.TaskFilterSpecRecursionOptionAll},
+				State:    []types.TaskInfoState{types.TaskInfoStateSuccess},
+				UserName: &types.TaskFilterSpecByUsername{UserList: []string{"root"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		spec := test.filter.Spec()
+		if !reflect.DeepEqual(spec, test.expect) {
+			t.Errorf("%s: %#v != %#v", test.name, spec, test.expect)
+		}
+	}
+}
+
+func TestQueryTasks(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		vms, err := find.NewFinder(c).VirtualMachineList(ctx, "*")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, vm := range vms {
+			pt, err := vm.PowerOff(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = pt.Wait(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		m := task.NewManager(c)
+		root := c.ServiceContent.RootFolder
+		filter := task.NewFilter().ByEntity(root, types.TaskFilterSpecRecursionOptionAll).Spec()
+
+		all, err := m.QueryTasks(ctx, filter, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(all) < len(vms) {
+			t.Fatalf("expected %d tasks, got %d", len(vms), len(all))
+		}
+
+		for i := 1; i < len(all); i++ {
+			if all[i].QueueTime.Before(all[i-1].QueueTime) {
+				t.Errorf("%s queued before %s", all[i].Key, all[i-1].Key)
+			}
+		}
+
+		for _, size := range []int32{0, 2} {
+			tasks, err := m.QueryTasks(ctx, filter, size)
+			if err != nil {
+				t.Fatalf("pageSize=%d: %s", size, err)
+			}
+
+			if !reflect.DeepEqual(keys(tasks), keys(all)) {
+				t.Errorf("pageSize=%d: %v != %v", size, keys(tasks), keys(all))
+			}
+		}
+
+		vm := vms[0].Reference()
+		filter = task.NewFilter().ByEntity(vm, types.TaskFilterSpecRecursionOptionSelf).ByState(types.TaskInfoStateSuccess).Spec()
+		tasks, err := m.QueryTasks(ctx, filter, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// powered on when the model was created, then powered off above
+		if len(tasks) != 2 || tasks[1].DescriptionId != "VirtualMachine.powerOff" {
+			t.Fatalf("tasks=%v", keys(tasks))
+		}
+		for _, info := range tasks {
+			if *info.Entity != vm {
+				t.Errorf("%s entity=%s", info.Key, *info.Entity)
+			}
+		}
+
+		// page backwards from the latest task
+		collector, err := m.CreateCollectorForTasks(ctx, task.NewFilter().ByEntity(root, types.TaskFilterSpecRecursionOptionAll).Spec())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = collector.Destroy(ctx)
+		}()
+
+		latest, err := collector.LatestPage(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(latest) == 0 || latest[0].Key != all[len(all)-1].Key {
+			t.Errorf("latestPage=%v", keys(latest))
+		}
+
+		var previous []types.TaskInfo
+		for {
+			page, err := collector.ReadPreviousTasks(ctx, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			previous = append(previous, page...)
+		}
+
+		if len(previous) != len(all) {
+			t.Fatalf("%d previous tasks, expected %d", len(previous), len(all))
+		}
+		for i := range previous {
+			if previous[i].Key != all[len(all)-1-i].Key {
+				t.Errorf("%d: %s", i, previous[i].Key)
+			}
+		}
+	})
+}
+
+func keys(tasks []types.TaskInfo) []string {
+	var keys []string
+	for _, task := range tasks {
+		keys = append(keys, task.Key)
+	}
+	return keys
+}
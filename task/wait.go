@@ -38,7 +38,11 @@ func (t taskProgress) Detail() string {
 
 func (t taskProgress) Error() error {
 	if t.info.Error != nil {
-		return Error{t.info.Error, t.info.Description}
+		return Error{
+			LocalizedMethodFault: t.info.Error,
+			Description:          t.info.Description,
+			Info:                 t.info,
+		}
 	}
 
 	return nil
@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package view
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Cache is an inventory cache of object properties, populated via a ContainerView and
+// kept current by a background WaitForUpdatesEx loop, rather than by repeated RetrieveProperties calls.
+// A Cache is safe for concurrent use.
+type Cache struct {
+	tracker *property.Tracker
+
+	mu      sync.Mutex
+	objects map[types.ManagedObjectReference]bool
+	err     error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewCache creates a ContainerView of the given types under root and returns a Cache of the properties ps
+// of the objects in the view, once the initial contents have been received.
+// The Cache is updated until ctx is canceled or Close is called.
+func (m Manager) NewCache(ctx context.Context, root types.ManagedObjectReference, kind []string, ps []string) (*Cache, error) {
+	v, err := m.CreateContainerView(ctx, root, kind, true)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := new(property.WaitFilter)
+	filter.Spec.ObjectSet = []types.ObjectSpec{{
+		Obj:       v.Reference(),
+		Skip:      types.NewBool(true),
+		SelectSet: []types.BaseSelectionSpec{v.TraversalSpec()},
+	}}
+
+	for _, t := range kind {
+		spec := types.PropertySpec{
+			Type:    t,
+			PathSet: ps,
+		}
+		if len(ps) == 0 {
+			spec.All = types.NewBool(true)
+		}
+		filter.Spec.PropSet = append(filter.Spec.PropSet, spec)
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+
+	c := &Cache{
+		tracker: property.NewTracker(),
+		objects: make(map[types.ManagedObjectReference]bool),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	ready := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		defer close(c.done)
+
+		pc := property.DefaultCollector(v.Client())
+
+		err := property.WaitForUpdates(wctx, pc, filter, func(updates []types.ObjectUpdate) bool {
+			c.apply(updates)
+			if !filter.Truncated {
+				once.Do(func() { close(ready) })
+			}
+			return false
+		})
+
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+
+		once.Do(func() { close(ready) })
+
+		_ = v.Destroy(context.Background())
+	}()
+
+	select {
+	case <-ready:
+		if err := c.Err(); err != nil {
+			cancel()
+			return nil, err
+		}
+		return c, nil
+	case <-ctx.Done():
+		cancel()
+		<-c.done
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Cache) apply(updates []types.ObjectUpdate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, update := range updates {
+		if update.Kind == types.ObjectUpdateKindLeave {
+			delete(c.objects, update.Obj)
+		} else {
+			c.objects[update.Obj] = true
+		}
+	}
+
+	c.tracker.ApplyUpdateSet(types.UpdateSet{
+		FilterSet: []types.PropertyFilterUpdate{{ObjectSet: updates}},
+	})
+}
+
+// Objects returns the references of all cached objects of the given type, sorted by value.
+func (c *Cache) Objects(kind string) []types.ManagedObjectReference {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.refs(kind)
+}
+
+// refs returns the references of all cached objects of the given type, sorted by value.
+// The caller must hold c.mu.
+func (c *Cache) refs(kind string) []types.ManagedObjectReference {
+	var refs []types.ManagedObjectReference
+	for obj := range c.objects {
+		if obj.Type == kind {
+			refs = append(refs, obj)
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Value < refs[j].Value
+	})
+
+	return refs
+}
+
+// Load populates dst with the cached properties of all objects of the given type,
+// as property.Collector.Retrieve does, for example with a *[]mo.VirtualMachine.
+// The objects and their properties are read from the same version of the updates.
+func (c *Cache) Load(kind string, dst interface{}) error {
+	var content []types.ObjectContent
+
+	c.mu.Lock()
+	for _, obj := range c.refs(kind) {
+		props := c.tracker.Current(obj)
+		if props == nil {
+			continue
+		}
+
+		oc := types.ObjectContent{Obj: obj}
+		for name, val := range props {
+			oc.PropSet = append(oc.PropSet, types.DynamicProperty{Name: name, Val: val})
+		}
+
+		content = append(content, oc)
+	}
+	c.mu.Unlock()
+
+	return mo.LoadObjectContent(content, dst)
+}
+
+// Err returns the error that stopped the Cache's updates, if any.
+func (c *Cache) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+// Close stops updating the Cache and destroys its ContainerView.
+func (c *Cache) Close() error {
+	c.cancel()
+	<-c.done
+
+	return c.Err()
+}
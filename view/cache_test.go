@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package view_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestCache(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		m := view.NewManager(c)
+
+		cache, err := m.NewCache(ctx, c.ServiceContent.RootFolder, []string{"VirtualMachine"}, []string{"name", "runtime.powerState"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vms []mo.VirtualMachine
+		if err = cache.Load("VirtualMachine", &vms); err != nil {
+			t.Fatal(err)
+		}
+
+		n := len(simulator.Map.All("VirtualMachine"))
+		if len(vms) != n {
+			t.Fatalf("%d VMs, expected %d", len(vms), n)
+		}
+
+		vm := object.NewVirtualMachine(c, vms[0].Self)
+		if vms[0].Name == "" || vms[0].Runtime.PowerState != types.VirtualMachinePowerStatePoweredOn {
+			t.Errorf("vm=%s state=%s", vms[0].Name, vms[0].Runtime.PowerState)
+		}
+
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; ; i++ {
+			var o []mo.VirtualMachine
+			if err = cache.Load("VirtualMachine", &o); err != nil {
+				t.Fatal(err)
+			}
+			if o[0].Runtime.PowerState == types.VirtualMachinePowerStatePoweredOff {
+				break
+			}
+			if i == 100 {
+				t.Fatal("power state not updated")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if err = cache.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/vmware/govmomi/vim25/methods"
@@ -88,6 +90,26 @@ func NewClient(ctx context.Context, rt soap.RoundTripper) (*Client, error) {
 	return &c, nil
 }
 
+// RefreshServiceContent re-fetches the ServiceContent, which may have changed after a
+// server upgrade or failover, for example the About info and managed object references.
+// Requests in flight, such as a WaitForUpdatesEx long poll, are not affected.
+// The ServiceContent field is not guarded by the Client: RefreshServiceContent must be called
+// from a single goroutine, while no other goroutine reads the field.
+// The field is only replaced if the content has changed, such that a refresh which finds
+// no change, the common case after a reconnect, does not race with readers of the field.
+func (c *Client) RefreshServiceContent(ctx context.Context) error {
+	content, err := methods.GetServiceContent(ctx, c.RoundTripper)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(c.ServiceContent, content) {
+		c.ServiceContent = content
+	}
+
+	return nil
+}
+
 // UseServiceVersion sets soap.Client.Version to the current version of the service endpoint via /sdk/vimServiceVersions.xml
 func (c *Client) UseServiceVersion(kind ...string) error {
 	ns := "vim"
@@ -189,3 +211,57 @@ func (c *Client) Path() string {
 func (c *Client) IsVC() bool {
 	return c.ServiceContent.About.ApiType == "VirtualCenter"
 }
+
+// IsESXi returns true if we are connected to a standalone ESXi host
+func (c *Client) IsESXi() bool {
+	return c.ServiceContent.About.ApiType == "HostAgent"
+}
+
+// APIVersion returns the parsed ServiceContent.About.ApiVersion
+func (c *Client) APIVersion() APIVersion {
+	return ParseAPIVersion(c.ServiceContent.About.ApiVersion)
+}
+
+// APIVersion is a dotted version number, such as ServiceContent.About.ApiVersion "6.7.3"
+type APIVersion []int
+
+// ParseAPIVersion parses the given dotted version string.
+// Parsing stops at the first component that is not a number, such that "7.0.1.0" is [7 0 1 0] and "6.5u2" is [6].
+func ParseAPIVersion(s string) APIVersion {
+	var v APIVersion
+
+	for _, p := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		v = append(v, n)
+	}
+
+	return v
+}
+
+// AtLeast returns true if the version is greater than or equal to major.minor
+func (v APIVersion) AtLeast(major, minor int) bool {
+	var vmajor, vminor int
+	if len(v) > 0 {
+		vmajor = v[0]
+	}
+	if len(v) > 1 {
+		vminor = v[1]
+	}
+
+	if vmajor != major {
+		return vmajor > major
+	}
+
+	return vminor >= minor
+}
+
+func (v APIVersion) String() string {
+	s := make([]string, len(v))
+	for i := range v {
+		s[i] = strconv.Itoa(v[i])
+	}
+	return strings.Join(s, ".")
+}
@@ -21,7 +21,9 @@ import (
 	"encoding/json"
 	"net/url"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
@@ -95,3 +97,112 @@ func TestClientSerialization(t *testing.T) {
 	// Check the session is still valid
 	sessionCheck(t, c2)
 }
+
+func TestAPIVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		major   int
+		minor   int
+		expect  bool
+	}{
+		{"7.0.1.0", 7, 0, true},
+		{"7.0.1.0", 6, 7, true},
+		{"6.7.3", 7, 0, false},
+		{"6.5", 6, 5, true},
+		{"6.5", 6, 7, false},
+		{"6", 6, 0, true},
+		{"", 5, 5, false},
+	}
+
+	for _, test := range tests {
+		c := &Client{ServiceContent: types.ServiceContent{About: types.AboutInfo{ApiVersion: test.version}}}
+		v := c.APIVersion()
+		if v.AtLeast(test.major, test.minor) != test.expect {
+			t.Errorf("%s.AtLeast(%d, %d) != %t", test.version, test.major, test.minor, test.expect)
+		}
+		if v.String() != test.version {
+			t.Errorf("%s != %s", v, test.version)
+		}
+	}
+}
+
+// refreshRoundTripper returns ServiceContent.About.Version for RetrieveServiceContent calls,
+// CurrentTime calls block until done is closed, as a long poll would.
+type refreshRoundTripper struct {
+	mu      sync.Mutex
+	version string
+	started chan struct{}
+	done    chan struct{}
+}
+
+func (rt *refreshRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	switch res := res.(type) {
+	case *methods.RetrieveServiceContentBody:
+		rt.mu.Lock()
+		version := rt.version
+		rt.mu.Unlock()
+
+		res.Res = &types.RetrieveServiceContentResponse{
+			Returnval: types.ServiceContent{About: types.AboutInfo{Version: version}},
+		}
+	case *methods.CurrentTimeBody:
+		close(rt.started)
+		<-rt.done
+		res.Res = new(types.CurrentTimeResponse)
+	}
+	return nil
+}
+
+func TestRefreshServiceContent(t *testing.T) {
+	ctx := context.Background()
+	rt := &refreshRoundTripper{
+		version: "1",
+		started: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	c, err := NewClient(ctx, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error)
+	go func() {
+		_, err := methods.GetCurrentTime(ctx, c)
+		errs <- err
+	}()
+	<-rt.started
+
+	// A refresh does not wait for requests in flight
+	refresh := func(version string) {
+		rt.mu.Lock()
+		rt.version = version
+		rt.mu.Unlock()
+
+		res := make(chan error)
+		go func() {
+			res <- c.RefreshServiceContent(ctx)
+		}()
+
+		select {
+		case err := <-res:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("RefreshServiceContent blocked by a request in flight")
+		}
+
+		if c.ServiceContent.About.Version != version {
+			t.Errorf("version=%s, expected %s", c.ServiceContent.About.Version, version)
+		}
+	}
+
+	refresh("1")
+	refresh("2")
+
+	close(rt.done)
+	if err = <-errs; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -18,6 +18,8 @@ package mo
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/vmware/govmomi/vim25/methods"
@@ -84,17 +86,26 @@ func ApplyPropertyChange(obj Reference, changes []types.PropertyChange) {
 	}
 }
 
+// ErrNoObjects is returned by LoadObjectContent when dst is a single managed object
+// and the RetrieveProperties{Ex} response contains no objects.
+var ErrNoObjects = errors.New("no objects in property collector result")
+
 // LoadObjectContent converts the response of a call to
 // RetrieveProperties{Ex} to one or more managed objects.
+// If dst is a slice and content is empty, dst is set to an empty non-nil slice.
+// If dst is a single managed object and content is empty, ErrNoObjects is returned
+// and dst is left unchanged.
+// An error is returned if dst is not a pointer to a struct or slice of a type
+// the objects in content can be loaded into.
 func LoadObjectContent(content []types.ObjectContent, dst interface{}) error {
 	rt := reflect.TypeOf(dst)
 	if rt == nil || rt.Kind() != reflect.Ptr {
-		panic("need pointer")
+		return fmt.Errorf("dst must be a pointer, got %T", dst)
 	}
 
 	rv := reflect.ValueOf(dst).Elem()
 	if !rv.CanSet() {
-		panic("cannot set dst")
+		return fmt.Errorf("dst must be a non-nil pointer, got %T", dst)
 	}
 
 	isSlice := false
@@ -103,57 +114,67 @@ func LoadObjectContent(content []types.ObjectContent, dst interface{}) error {
 	case reflect.Slice:
 		isSlice = true
 	default:
-		panic("unexpected type")
+		return fmt.Errorf("dst must be a pointer to a struct or slice, got %T", dst)
 	}
 
 	if isSlice {
+		if rv.IsNil() {
+			rv.Set(reflect.MakeSlice(rv.Type(), 0, len(content)))
+		}
+
 		for _, p := range content {
 			v, err := ObjectContentToType(p)
 			if err != nil {
 				return err
 			}
 
-			vt := reflect.TypeOf(v)
-
-			if !rv.Type().AssignableTo(vt) {
-				// For example: dst is []ManagedEntity, res is []HostSystem
-				if field, ok := vt.FieldByName(rt.Elem().Elem().Name()); ok && field.Anonymous {
-					rv.Set(reflect.Append(rv, reflect.ValueOf(v).FieldByIndex(field.Index)))
-					continue
-				}
+			// For example: dst is []ManagedEntity, res is []HostSystem
+			val, ok := assignableValue(reflect.ValueOf(v), rt.Elem().Elem())
+			if !ok {
+				return fmt.Errorf("dst must be a pointer to a slice of %T for objects of type %s, got %T", v, p.Obj.Type, dst)
 			}
 
-			rv.Set(reflect.Append(rv, reflect.ValueOf(v)))
+			rv.Set(reflect.Append(rv, val))
 		}
 	} else {
 		switch len(content) {
 		case 0:
+			return ErrNoObjects
 		case 1:
 			v, err := ObjectContentToType(content[0])
 			if err != nil {
 				return err
 			}
 
-			vt := reflect.TypeOf(v)
-
-			if !rv.Type().AssignableTo(vt) {
-				// For example: dst is ComputeResource, res is ClusterComputeResource
-				if field, ok := vt.FieldByName(rt.Elem().Name()); ok && field.Anonymous {
-					rv.Set(reflect.ValueOf(v).FieldByIndex(field.Index))
-					return nil
-				}
+			// For example: dst is ComputeResource, res is ClusterComputeResource
+			val, ok := assignableValue(reflect.ValueOf(v), rt.Elem())
+			if !ok {
+				return fmt.Errorf("dst must be a pointer to %T for objects of type %s, got %T", v, content[0].Obj.Type, dst)
 			}
 
-			rv.Set(reflect.ValueOf(v))
+			rv.Set(val)
 		default:
 			// If dst is not a slice, expect to receive 0 or 1 results
-			panic("more than 1 result")
+			return fmt.Errorf("dst must be a pointer to a slice for %d objects, got %T", len(content), dst)
 		}
 	}
 
 	return nil
 }
 
+// assignableValue returns v if it is assignable to type t, otherwise v's embedded field of type t.
+func assignableValue(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Type().AssignableTo(t) {
+		return v, true
+	}
+
+	if field, ok := v.Type().FieldByName(t.Name()); ok && field.Anonymous && field.Type.AssignableTo(t) {
+		return v.FieldByIndex(field.Index), true
+	}
+
+	return reflect.Value{}, false
+}
+
 // RetrievePropertiesForRequest calls the RetrieveProperties method with the
 // specified request and decodes the response struct into the value pointed to
 // by dst.
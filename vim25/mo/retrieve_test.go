@@ -211,3 +211,76 @@ func TestEventReferences(t *testing.T) {
 		t.Errorf("%d refs", n)
 	}
 }
+
+func TestLoadObjectContentEmpty(t *testing.T) {
+	var vm VirtualMachine
+	vm.Name = "unchanged"
+
+	err := LoadObjectContent(nil, &vm)
+	if err != ErrNoObjects {
+		t.Errorf("err=%v", err)
+	}
+	if vm.Name != "unchanged" {
+		t.Errorf("dst modified: %q", vm.Name)
+	}
+
+	var vms []VirtualMachine
+
+	err = LoadObjectContent(nil, &vms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vms == nil || len(vms) != 0 {
+		t.Errorf("vms=%#v", vms)
+	}
+
+	var hosts []HostSystem
+
+	err = LoadObjectContent(load("fixtures/hostsystem_list_name_property.xml"), &hosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) == 0 {
+		t.Error("expected hosts")
+	}
+}
+
+func TestLoadObjectContentInvalidDst(t *testing.T) {
+	content := load("fixtures/hostsystem_list_name_property.xml")
+
+	var host HostSystem
+	var hosts []HostSystem
+	var vm VirtualMachine
+	var vms []VirtualMachine
+	var name string
+
+	tests := []struct {
+		content []types.ObjectContent
+		dst     interface{}
+	}{
+		{content, nil},
+		{content, hosts},
+		{content, (*[]HostSystem)(nil)},
+		{content, &name},
+		{content, &host}, // more than 1 result
+		{content, &vms},
+		{content[:1], &vm},
+	}
+
+	for i, test := range tests {
+		err := LoadObjectContent(test.content, test.dst)
+		if err == nil {
+			t.Errorf("%d: expected error for %T", i, test.dst)
+		}
+	}
+
+	err := LoadObjectContent(content, &hosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = LoadObjectContent(content[:1], &host)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
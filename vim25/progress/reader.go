@@ -42,6 +42,10 @@ func (r readerReport) Percentage() float32 {
 	return 100.0 * float32(r.pos) / float32(r.size)
 }
 
+func (r readerReport) Bytes() int64 {
+	return r.pos
+}
+
 func (r readerReport) Detail() string {
 	const (
 		KiB = 1024
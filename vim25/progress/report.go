@@ -24,3 +24,12 @@ type Report interface {
 	Detail() string
 	Error() error
 }
+
+// BytesReport is implemented by the reports of readers, such as those used for
+// uploads and downloads in the http client.
+type BytesReport interface {
+	Report
+
+	// Bytes returns the number of bytes read so far.
+	Bytes() int64
+}
@@ -18,9 +18,13 @@ package vim25
 
 import (
 	"context"
+	"errors"
+	"math/rand"
+	"syscall"
 	"time"
 
 	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 type RetryFunc func(err error) (retry bool, delay time.Duration)
@@ -61,6 +65,26 @@ func IsTemporaryNetworkError(err error) bool {
 	return t.Temporary()
 }
 
+// IsRetriableError returns true if IsTemporaryNetworkError(err) is true,
+// if err is a connection reset or if err is a TaskInProgress fault.
+func IsRetriableError(err error) bool {
+	if IsTemporaryNetworkError(err) {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	if soap.IsSoapFault(err) {
+		if _, ok := soap.ToSoapFault(err).VimFault().(types.TaskInProgress); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 type retry struct {
 	roundTripper soap.RoundTripper
 
@@ -69,6 +93,11 @@ type retry struct {
 	// delay before retrying.
 	fn               RetryFunc
 	maxRetryAttempts int
+
+	// backoff, if set, is the initial delay of exponential backoff between attempts,
+	// overriding the delay returned by fn.
+	backoff    time.Duration
+	maxBackoff time.Duration
 }
 
 // Retry wraps the specified soap.RoundTripper and invokes the
@@ -91,6 +120,39 @@ func Retry(roundTripper soap.RoundTripper, fn RetryFunc, retryAttempts ...int) s
 	return r
 }
 
+// RetryWithBackoff wraps the specified soap.RoundTripper, retrying up to maxAttempts
+// when isRetriable returns true for the error, such as IsRetriableError.
+// The delay between attempts starts at base and doubles with each attempt, up to max,
+// with random jitter of up to half the delay.
+// Retries stop when the context is canceled, returning the context's error.
+func RetryWithBackoff(roundTripper soap.RoundTripper, isRetriable func(error) bool, maxAttempts int, base, max time.Duration) soap.RoundTripper {
+	return &retry{
+		roundTripper: roundTripper,
+		fn: func(err error) (bool, time.Duration) {
+			return isRetriable(err), 0
+		},
+		maxRetryAttempts: maxAttempts,
+		backoff:          base,
+		maxBackoff:       max,
+	}
+}
+
+// delay returns the backoff delay before the given retry attempt.
+func (r *retry) delay(attempt int) time.Duration {
+	d := r.maxBackoff
+	if attempt < 32 {
+		if n := r.backoff << uint(attempt); n > 0 && n < d {
+			d = n
+		}
+	}
+
+	if half := int64(d / 2); half > 0 {
+		d = time.Duration(half + rand.Int63n(half+1))
+	}
+
+	return d
+}
+
 func (r *retry) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
 	var err error
 
@@ -101,12 +163,20 @@ func (r *retry) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
 		}
 
 		// Invoke retry function to see if another attempt should be made.
-		if retry, delay := r.fn(err); retry {
-			time.Sleep(delay)
-			continue
+		retry, delay := r.fn(err)
+		if !retry || attempt+1 == r.maxRetryAttempts {
+			break
 		}
 
-		break
+		if r.backoff > 0 {
+			delay = r.delay(attempt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
 	}
 
 	return err
@@ -132,3 +132,38 @@ func TestRetryNetworkError(t *testing.T) {
 		simulator.StatusSDK = http.StatusOK
 	})
 }
+
+func TestRetryWithBackoff(t *testing.T) {
+	rt := &fakeRoundTripper{errs: []error{tempError{}, tempError{}, nil}}
+
+	err := vim25.RetryWithBackoff(rt, vim25.IsRetriableError, 3, time.Millisecond, 10*time.Millisecond).RoundTrip(context.Background(), nil, nil)
+	if err != nil {
+		t.Errorf("unexpected error=%s", err)
+	}
+
+	rt = &fakeRoundTripper{errs: []error{tempError{}, tempError{}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = vim25.RetryWithBackoff(rt, vim25.IsRetriableError, 2, time.Hour, time.Hour).RoundTrip(ctx, nil, nil)
+	if err != context.Canceled {
+		t.Errorf("unexpected error=%v", err)
+	}
+
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		c.RoundTripper = vim25.RetryWithBackoff(c.Client, vim25.IsRetriableError, 2, time.Millisecond, time.Millisecond)
+
+		vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Tell vcsim to respond with 503 on the 1st request
+		simulator.StatusSDK = http.StatusServiceUnavailable
+
+		_, err = vm.PowerState(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -164,10 +164,42 @@ func NewClient(u *url.URL, insecure bool) *Client {
 	return &c
 }
 
+// DefaultTransport returns the http.Transport used by the Client.
 func (c *Client) DefaultTransport() *http.Transport {
 	return c.t
 }
 
+// SetMaxIdleConns sets the maximum number of idle connections kept in the DefaultTransport pool,
+// in total and per host. Zero means no limit in total and http.DefaultMaxIdleConnsPerHost per host.
+func (c *Client) SetMaxIdleConns(total, perHost int) {
+	c.t.MaxIdleConns = total
+	c.t.MaxIdleConnsPerHost = perHost
+}
+
+// SetMaxConnsPerHost limits the total number of connections per host, including connections
+// in use. Requests beyond the limit wait for a connection. Zero means no limit.
+func (c *Client) SetMaxConnsPerHost(n int) {
+	c.t.MaxConnsPerHost = n
+}
+
+// SetIdleConnTimeout sets the maximum amount of time an idle connection remains in the
+// DefaultTransport pool before closing itself. Zero means no limit.
+func (c *Client) SetIdleConnTimeout(d time.Duration) {
+	c.t.IdleConnTimeout = d
+}
+
+// SetProxy configures the client to send all requests via the given proxy URL,
+// which may use the http, https or socks5 scheme.
+// This includes datastore and NFC lease transfers to ESX hosts made with this client.
+// If u is nil, the proxy is determined by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func (c *Client) SetProxy(u *url.URL) {
+	if u == nil {
+		c.t.Proxy = http.ProxyFromEnvironment
+	} else {
+		c.t.Proxy = http.ProxyURL(u)
+	}
+}
+
 // NewServiceClient creates a NewClient with the given URL.Path and namespace.
 func (c *Client) NewServiceClient(path string, namespace string) *Client {
 	vc := c.URL()
@@ -183,6 +215,10 @@ func (c *Client) NewServiceClient(path string, namespace string) *Client {
 	client := NewClient(u, c.k)
 	client.Namespace = "urn:" + namespace
 	client.DefaultTransport().TLSClientConfig = c.DefaultTransport().TLSClientConfig
+	client.DefaultTransport().Proxy = c.DefaultTransport().Proxy
+	client.SetMaxIdleConns(c.t.MaxIdleConns, c.t.MaxIdleConnsPerHost)
+	client.SetMaxConnsPerHost(c.t.MaxConnsPerHost)
+	client.SetIdleConnTimeout(c.t.IdleConnTimeout)
 	if cert := c.Certificate(); cert != nil {
 		client.SetCertificate(*cert)
 	}
@@ -252,6 +288,15 @@ func (c *Client) SetRootCAs(pemPaths string) error {
 	return nil
 }
 
+// SetRootCertPool sets the pool of root certificate authorities the client uses when verifying
+// server certificates, such as the CA of an internal PKI, instead of the host's root CA set.
+// Thumbprint verification via SetThumbprint remains in effect for certificates not signed by the pool.
+//
+// See: http.Client.Transport.TLSClientConfig.RootCAs
+func (c *Client) SetRootCertPool(pool *x509.CertPool) {
+	c.t.TLSClientConfig.RootCAs = pool
+}
+
 // Add default https port if missing
 func hostAddr(addr string) string {
 	_, port := splitHostPort(addr)
@@ -351,10 +396,9 @@ func (c *Client) dialTLS(network string, addr string) (net.Conn, error) {
 		return conn, nil
 	}
 
-	switch err.(type) {
-	case x509.UnknownAuthorityError:
-	case x509.HostnameError:
-	default:
+	var uae x509.UnknownAuthorityError
+	var he x509.HostnameError
+	if !errors.As(err, &uae) && !errors.As(err, &he) {
 		return nil, err
 	}
 
@@ -508,8 +552,21 @@ func (c *Client) Do(ctx context.Context, req *http.Request, f func(*http.Respons
 	}
 
 	ext := ""
+	name := ""
 	if d.enabled() {
+		ctx = d.withRequestID(ctx)
 		ext = d.debugRequest(req)
+		if kind, ok := ctx.Value(kindContext{}).(HasFault); ok {
+			name = fmt.Sprintf("%T", kind)
+		} else {
+			name = fmt.Sprintf("%s %s", req.Method, req.URL)
+		}
+		d.logf("request (%s) id=%s", name, d.id)
+	}
+
+	n, count := ctx.Value(byteCountContext{}).(*byteCount)
+	if count && req.Body != nil {
+		req.Body = countReader{req.Body, &n.out}
 	}
 
 	tstart := time.Now()
@@ -517,13 +574,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, f func(*http.Respons
 	tstop := time.Now()
 
 	if d.enabled() {
-		var name string
-		if kind, ok := ctx.Value(kindContext{}).(HasFault); ok {
-			name = fmt.Sprintf("%T", kind)
-		} else {
-			name = fmt.Sprintf("%s %s", req.Method, req.URL)
-		}
-		d.logf("%6dms (%s)", tstop.Sub(tstart)/time.Millisecond, name)
+		d.logf("%6dms (%s) id=%s", tstop.Sub(tstart)/time.Millisecond, name, d.id)
 	}
 
 	if err != nil {
@@ -538,6 +589,10 @@ func (c *Client) Do(ctx context.Context, req *http.Request, f func(*http.Respons
 		c.setInsecureCookies(res)
 	}
 
+	if count {
+		res.Body = countReader{res.Body, &n.in}
+	}
+
 	defer res.Body.Close()
 
 	return f(res)
@@ -565,7 +620,7 @@ type statusError struct {
 // See vim25.IsTemporaryNetworkError
 func (e *statusError) Temporary() bool {
 	switch e.res.StatusCode {
-	case http.StatusBadGateway:
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
 		return true
 	}
 	return false
@@ -656,6 +711,8 @@ func (c *Client) RoundTrip(ctx context.Context, reqBody, resBody HasFault) error
 	})
 }
 
+// CloseIdleConnections closes any connections in the DefaultTransport pool which are
+// currently idle, without interrupting connections that are in use.
 func (c *Client) CloseIdleConnections() {
 	c.t.CloseIdleConnections()
 }
@@ -770,12 +827,29 @@ type Download struct {
 	Ticket   *http.Cookie
 	Progress progress.Sinker
 	Writer   io.Writer
+	// Offset, if greater than 0, requests the remote file starting at the given byte offset,
+	// using an HTTP Range header. DownloadFile writes to the local file starting at the same offset,
+	// such that a partial download can be resumed. The progress reports of DownloadFile
+	// implement progress.BytesReport, such that Offset plus Bytes() is the offset to resume from.
+	Offset int64
 }
 
 var DefaultDownload = Download{
 	Method: "GET",
 }
 
+// SizeMismatchError is returned by DownloadFile when the number of bytes written
+// does not match the expected length of the remote file.
+type SizeMismatchError struct {
+	Name     string
+	Expected int64
+	Actual   int64
+}
+
+func (e SizeMismatchError) Error() string {
+	return fmt.Sprintf("%s: expected %d bytes, wrote %d", e.Name, e.Expected, e.Actual)
+}
+
 // DownloadRequest wraps http.Client.Do, returning the http.Response without checking its StatusCode
 func (c *Client) DownloadRequest(ctx context.Context, u *url.URL, param *Download) (*http.Response, error) {
 	req, err := http.NewRequest(param.Method, u.String(), nil)
@@ -789,6 +863,10 @@ func (c *Client) DownloadRequest(ctx context.Context, u *url.URL, param *Downloa
 		req.Header.Add(k, v)
 	}
 
+	if param.Offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", param.Offset))
+	}
+
 	if param.Ticket != nil {
 		req.AddCookie(param.Ticket)
 	}
@@ -805,11 +883,25 @@ func (c *Client) Download(ctx context.Context, u *url.URL, param *Download) (io.
 
 	switch res.StatusCode {
 	case http.StatusOK:
+		if param.Offset > 0 {
+			err = fmt.Errorf("download(%s): range request not supported", u)
+		}
+	case http.StatusPartialContent:
+		if param.Offset <= 0 {
+			err = fmt.Errorf("download(%s): %s", u, res.Status)
+			break
+		}
+		var start int64
+		cr := res.Header.Get("Content-Range")
+		if _, serr := fmt.Sscanf(cr, "bytes %d-", &start); serr != nil || start != param.Offset {
+			err = fmt.Errorf("download(%s): Content-Range %q does not start at offset %d", u, cr, param.Offset)
+		}
 	default:
 		err = fmt.Errorf("download(%s): %s", u, res.Status)
 	}
 
 	if err != nil {
+		_ = res.Body.Close()
 		return nil, 0, err
 	}
 
@@ -818,14 +910,57 @@ func (c *Client) Download(ctx context.Context, u *url.URL, param *Download) (io.
 	return r, res.ContentLength, nil
 }
 
+// contextReader fails a Read once its Context is done, rather than waiting for the next Read to return.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
 func (c *Client) WriteFile(ctx context.Context, file string, src io.Reader, size int64, s progress.Sinker, w io.Writer) error {
+	_, err := c.writeFile(ctx, file, src, size, 0, s, w)
+	return err
+}
+
+// writeFile writes src to file starting at the given offset, returning the number of bytes written.
+func (c *Client) writeFile(ctx context.Context, file string, src io.Reader, size int64, offset int64, s progress.Sinker, w io.Writer) (int64, error) {
 	var err error
 
 	r := src
 
-	fh, err := os.Create(file)
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if offset > 0 {
+		flag = os.O_WRONLY
+
+		// The file must contain at least offset bytes, Truncate would otherwise fill the gap with zeros.
+		info, serr := os.Stat(file)
+		if serr != nil {
+			return 0, serr
+		}
+		if offset > info.Size() {
+			return 0, fmt.Errorf("%s: offset %d exceeds file size %d", file, offset, info.Size())
+		}
+	}
+
+	fh, err := os.OpenFile(file, flag, 0666)
 	if err != nil {
-		return err
+		return 0, err
+	}
+
+	if offset > 0 {
+		if err = fh.Truncate(offset); err == nil {
+			_, err = fh.Seek(offset, io.SeekStart)
+		}
+		if err != nil {
+			_ = fh.Close()
+			return 0, err
+		}
 	}
 
 	if s != nil {
@@ -844,7 +979,7 @@ func (c *Client) WriteFile(ctx context.Context, file string, src io.Reader, size
 		w = io.MultiWriter(w, fh)
 	}
 
-	_, err = io.Copy(w, r)
+	n, err := io.Copy(w, r)
 
 	cerr := fh.Close()
 
@@ -852,10 +987,12 @@ func (c *Client) WriteFile(ctx context.Context, file string, src io.Reader, size
 		err = cerr
 	}
 
-	return err
+	return n, err
 }
 
-// DownloadFile GETs the given URL to a local file
+// DownloadFile GETs the given URL to a local file.
+// If the remote file length is known and does not match the number of bytes written,
+// a SizeMismatchError is returned.
 func (c *Client) DownloadFile(ctx context.Context, file string, u *url.URL, param *Download) error {
 	var err error
 	if param == nil {
@@ -867,5 +1004,18 @@ func (c *Client) DownloadFile(ctx context.Context, file string, u *url.URL, para
 		return err
 	}
 
-	return c.WriteFile(ctx, file, rc, contentLength, param.Progress, param.Writer)
+	defer rc.Close()
+
+	src := contextReader{ctx, rc}
+
+	n, err := c.writeFile(ctx, file, src, contentLength, param.Offset, param.Progress, param.Writer)
+	if err != nil {
+		return err
+	}
+
+	if contentLength >= 0 && n != contentLength {
+		return SizeMismatchError{Name: file, Expected: param.Offset + contentLength, Actual: param.Offset + n}
+	}
+
+	return nil
 }
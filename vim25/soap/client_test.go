@@ -17,9 +17,27 @@ limitations under the License.
 package soap
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSplitHostPort(t *testing.T) {
@@ -97,3 +115,257 @@ func setCAsOnClient(cas string) error {
 
 	return client.SetRootCAs(cas)
 }
+
+func TestDownloadFileOffset(t *testing.T) {
+	ctx := context.Background()
+	content := "0123456789"
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/norange":
+			_, _ = io.WriteString(w, content)
+			return
+		case "/badrange":
+			// responds with a range other than the one requested
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = io.WriteString(w, content)
+			return
+		}
+		http.ServeContent(w, r, "file", time.Now(), strings.NewReader(content))
+	}))
+	defer s.Close()
+
+	u, _ := url.Parse(s.URL)
+	c := NewClient(u, true)
+
+	dir, err := ioutil.TempDir("", "govmomi-download")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "file")
+
+	check := func() {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Errorf("content=%q", b)
+		}
+	}
+
+	err = c.DownloadFile(ctx, file, u, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check()
+
+	// simulate a partial download, with some trailing garbage
+	err = ioutil.WriteFile(file, []byte("0123xx"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	param := DefaultDownload
+	param.Offset = 4
+
+	err = c.DownloadFile(ctx, file, u, &param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check()
+
+	badrange := *u
+	badrange.Path = "/badrange"
+
+	err = c.DownloadFile(ctx, file, &badrange, &param)
+	if err == nil {
+		t.Error("expected error")
+	}
+	check()
+
+	// offset beyond the end of the partial download
+	err = ioutil.WriteFile(file, []byte("01"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.DownloadFile(ctx, file, u, &param)
+	if err == nil {
+		t.Error("expected error")
+	}
+	if b, _ := ioutil.ReadFile(file); string(b) != "01" {
+		t.Errorf("content=%q", b)
+	}
+
+	norange := *u
+	norange.Path = "/norange"
+
+	err = c.DownloadFile(ctx, file, &norange, &param)
+	if err == nil {
+		t.Error("expected error")
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	err = c.DownloadFile(cctx, file, u, nil)
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSetRootCertPool(t *testing.T) {
+	newKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+
+	caKey := newKey()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "govmomi test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafKey := newKey()
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err = x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	s.Config.ErrorLog = log.New(ioutil.Discard, "", 0) // silence benign "TLS handshake error" log messages
+	s.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: leafKey}},
+	}
+	s.StartTLS()
+	defer s.Close()
+
+	u, _ := url.Parse(s.URL)
+	ctx := context.Background()
+
+	download := func(c *Client) error {
+		rc, _, err := c.Download(ctx, u, &DefaultDownload)
+		if err == nil {
+			_ = rc.Close()
+		}
+		return err
+	}
+
+	c := NewClient(u, false)
+	if err = download(c); err == nil {
+		t.Error("expected error without trusted CA")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	c = NewClient(u, false)
+	c.SetRootCertPool(pool)
+	if err = download(c); err != nil {
+		t.Errorf("trusted CA: %s", err)
+	}
+
+	c = NewClient(u, false)
+	c.SetRootCertPool(x509.NewCertPool())
+	if err = download(c); err == nil {
+		t.Error("expected error with untrusted CA")
+	}
+}
+
+func TestSetProxy(t *testing.T) {
+	var host string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.URL.Host
+		_, _ = io.WriteString(w, "proxied")
+	}))
+	defer proxy.Close()
+
+	pu, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u := &url.URL{Scheme: "http", Host: "vcenter.invalid", Path: "/sdk"}
+	c := NewClient(u, true)
+	c.SetProxy(pu)
+
+	sc := c.NewServiceClient("/folder", "vim25")
+
+	for _, client := range []*Client{c, sc} {
+		host = ""
+
+		rc, _, err := client.Download(context.Background(), &url.URL{Scheme: "http", Host: "esx.invalid", Path: "/nfc/disk.vmdk"}, &DefaultDownload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := ioutil.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != "proxied" || host != "esx.invalid" {
+			t.Errorf("body=%q host=%q", b, host)
+		}
+	}
+}
+
+func TestConnectionPool(t *testing.T) {
+	u := &url.URL{Scheme: "https", Host: "vcenter.invalid", Path: "/sdk"}
+	c := NewClient(u, true)
+
+	c.SetMaxIdleConns(100, 20)
+	c.SetMaxConnsPerHost(50)
+	c.SetIdleConnTimeout(time.Minute)
+
+	sc := c.NewServiceClient("/pbm", "pbm")
+
+	for _, client := range []*Client{c, sc} {
+		tr := client.DefaultTransport()
+
+		if tr.MaxIdleConns != 100 || tr.MaxIdleConnsPerHost != 20 {
+			t.Errorf("MaxIdleConns=%d MaxIdleConnsPerHost=%d", tr.MaxIdleConns, tr.MaxIdleConnsPerHost)
+		}
+
+		if tr.MaxConnsPerHost != 50 {
+			t.Errorf("MaxConnsPerHost=%d", tr.MaxConnsPerHost)
+		}
+
+		if tr.IdleConnTimeout != time.Minute {
+			t.Errorf("IdleConnTimeout=%s", tr.IdleConnTimeout)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package soap
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -39,10 +40,37 @@ var (
 type debugRoundTrip struct {
 	cn  uint64         // Client number
 	rn  uint64         // Request number
+	id  string         // Request ID
 	log io.WriteCloser // Request log
 	cs  []io.Closer    // Files that need closing when done
 }
 
+type requestIDContext struct{}
+
+// WithRequestID returns a copy of ctx carrying the given request ID.
+// When debug tracing is enabled, the ID is included in the client log for both the request and its response.
+// If ctx does not carry a request ID, one is generated by Client.Do from the client and request numbers.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContext{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, if any.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContext{}).(string)
+	return id, ok
+}
+
+// withRequestID sets the round trip ID from ctx, generating and propagating a new one if needed.
+func (d *debugRoundTrip) withRequestID(ctx context.Context) context.Context {
+	if id, ok := RequestID(ctx); ok {
+		d.id = id
+		return ctx
+	}
+
+	d.id = fmt.Sprintf("%d-%04d", d.cn, d.rn)
+	return WithRequestID(ctx, d.id)
+}
+
 func (d *debugRoundTrip) logf(format string, a ...interface{}) {
 	now := time.Now().Format("2006-01-02T15-04-05.000000000")
 	fmt.Fprintf(d.log, "%s - %04d: ", now, d.rn)
@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soap
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/debug"
+)
+
+type bufferProvider struct {
+	mu    sync.Mutex
+	files map[string]*bytes.Buffer
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func (p *bufferProvider) NewFile(s string) io.WriteCloser {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.files[s] == nil {
+		p.files[s] = new(bytes.Buffer)
+	}
+
+	return nopCloser{p.files[s]}
+}
+
+func (p *bufferProvider) Flush() {}
+
+func TestRequestID(t *testing.T) {
+	p := &bufferProvider{files: make(map[string]*bytes.Buffer)}
+	debug.SetProvider(p)
+	defer debug.SetProvider(nil)
+
+	var ids []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	u, _ := url.Parse(s.URL)
+	c := NewClient(u, true)
+	c.Client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		id, _ := RequestID(req.Context())
+		ids = append(ids, id)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	do := func(ctx context.Context) {
+		req, _ := http.NewRequest(http.MethodPost, s.URL, strings.NewReader("<x/>"))
+		err := c.Do(ctx, req, func(*http.Response) error { return nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	do(ctx)
+	do(WithRequestID(ctx, "my-request"))
+
+	generated := strings.SplitN(ids[0], "-", 2)
+	if len(generated) != 2 || generated[1] != "0001" {
+		t.Errorf("generated id=%q", ids[0])
+	}
+	if ids[1] != "my-request" {
+		t.Errorf("id=%q", ids[1])
+	}
+
+	var log string
+	for name, buf := range p.files {
+		if strings.HasSuffix(name, "-client.log") {
+			log = buf.String()
+		}
+	}
+
+	for _, id := range ids {
+		if n := strings.Count(log, "id="+id+"\n"); n != 2 {
+			t.Errorf("id=%s logged %d times:\n%s", id, n, log)
+		}
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -59,6 +59,25 @@ func (s soapFaultError) MarshalJSON() ([]byte, error) {
 	return json.Marshal(out)
 }
 
+// Fault returns the fault detail as a pointer to its method fault type, such as *types.NotAuthenticated,
+// so SOAP faults can be inspected in the same way as task and vim faults, for example with types.IsFileNotFound.
+// Nil is returned if the detail is not a method fault.
+func (s soapFaultError) Fault() types.BaseMethodFault {
+	v := reflect.ValueOf(s.fault.Detail.Fault)
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() != reflect.Ptr {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+
+	f, _ := v.Interface().(types.BaseMethodFault)
+	return f
+}
+
 type vimFaultError struct {
 	fault types.BaseMethodFault
 }
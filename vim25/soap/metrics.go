@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soap
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MethodMetrics contains the round trip metrics of a single SOAP method.
+type MethodMetrics struct {
+	Count       int64         // Number of round trips
+	Errors      int64         // Number of round trips that returned an error, including faults
+	Faults      int64         // Number of round trips that returned a SOAP fault
+	Latency     time.Duration // Total latency of all round trips
+	LastLatency time.Duration // Latency of the most recent round trip
+	BytesOut    int64         // Total request body bytes
+	BytesIn     int64         // Total response body bytes
+}
+
+// Metrics is a RoundTripper wrapper that records round trip metrics per SOAP method.
+// It can be assigned to vim25.Client.RoundTripper, wrapping the existing RoundTripper.
+// Request and response sizes are only recorded when the wrapped RoundTripper is a soap.Client.
+type Metrics struct {
+	roundTripper RoundTripper
+
+	mu      sync.Mutex
+	methods map[string]*MethodMetrics
+}
+
+type byteCountContext struct{}
+
+type byteCount struct {
+	in, out int64
+}
+
+type countReader struct {
+	io.ReadCloser
+	n *int64
+}
+
+func (r countReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	*r.n += int64(n)
+	return n, err
+}
+
+// NewMetrics returns a Metrics RoundTripper wrapping the given RoundTripper.
+func NewMetrics(roundTripper RoundTripper) *Metrics {
+	return &Metrics{
+		roundTripper: roundTripper,
+		methods:      make(map[string]*MethodMetrics),
+	}
+}
+
+// methodName returns the SOAP method name of the given request body, for example "RetrieveProperties".
+func methodName(req HasFault) string {
+	t := reflect.TypeOf(req)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return strings.TrimSuffix(t.Name(), "Body")
+}
+
+// RoundTrip dispatches to the wrapped RoundTripper, recording metrics for the request's method.
+func (m *Metrics) RoundTrip(ctx context.Context, req, res HasFault) error {
+	n := new(byteCount)
+	ctx = context.WithValue(ctx, byteCountContext{}, n)
+
+	start := time.Now()
+	err := m.roundTripper.RoundTrip(ctx, req, res)
+	latency := time.Since(start)
+
+	name := methodName(req)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.methods[name]
+	if !ok {
+		s = new(MethodMetrics)
+		m.methods[name] = s
+	}
+
+	s.Count++
+	if err != nil {
+		s.Errors++
+		if IsSoapFault(err) {
+			s.Faults++
+		}
+	}
+	s.Latency += latency
+	s.LastLatency = latency
+	s.BytesOut += n.out
+	s.BytesIn += n.in
+
+	return err
+}
+
+// Snapshot returns a copy of the current metrics, keyed by SOAP method name.
+func (m *Metrics) Snapshot() map[string]MethodMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := make(map[string]MethodMetrics, len(m.methods))
+	for name, metrics := range m.methods {
+		s[name] = *metrics
+	}
+
+	return s
+}
+
+// Reset clears all recorded metrics.
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.methods = make(map[string]*MethodMetrics)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestMetrics(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		m := soap.NewMetrics(c.RoundTripper)
+		c.RoundTripper = m
+
+		for i := 0; i < 2; i++ {
+			_, err := methods.GetCurrentTime(ctx, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		req := types.Destroy_Task{
+			This: types.ManagedObjectReference{Type: "VirtualMachine", Value: "enoent"},
+		}
+		_, err := methods.Destroy_Task(ctx, c, &req)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		s := m.Snapshot()
+
+		ct := s["CurrentTime"]
+		if ct.Count != 2 || ct.Errors != 0 {
+			t.Errorf("CurrentTime: %#v", ct)
+		}
+		if ct.BytesIn == 0 || ct.BytesOut == 0 || ct.Latency == 0 || ct.LastLatency == 0 {
+			t.Errorf("CurrentTime: %#v", ct)
+		}
+
+		d := s["Destroy_Task"]
+		if d.Count != 1 || d.Errors != 1 || d.Faults != 1 {
+			t.Errorf("Destroy_Task: %#v", d)
+		}
+
+		m.Reset()
+		if len(m.Snapshot()) != 0 {
+			t.Error("expected empty snapshot")
+		}
+	})
+}
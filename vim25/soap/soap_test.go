@@ -19,6 +19,7 @@ package soap
 import (
 	"testing"
 
+	"github.com/vmware/govmomi/vim25/types"
 	"github.com/vmware/govmomi/vim25/xml"
 )
 
@@ -61,3 +62,26 @@ func TestNonEmptyHeader(t *testing.T) {
 		t.Errorf("ID=%s", env.Header.ID)
 	}
 }
+
+func TestSoapFaultFault(t *testing.T) {
+	f := &Fault{Code: "ServerFaultCode"}
+	f.Detail.Fault = types.FileNotFound{}
+
+	err := WrapSoapFault(f)
+	if !types.IsFileNotFound(err) {
+		t.Errorf("expected FileNotFound: %#v", err)
+	}
+	if types.IsDuplicateName(err) {
+		t.Error("unexpected DuplicateName")
+	}
+
+	f.Detail.Fault = &types.InvalidPowerState{}
+	if !types.IsInvalidPowerState(err) {
+		t.Errorf("expected InvalidPowerState: %#v", err)
+	}
+
+	f.Detail.Fault = nil
+	if err.(types.HasFault).Fault() != nil {
+		t.Error("expected nil fault")
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// Valid methods report whether an enum value is one defined by the schema,
+// for commonly compared runtime properties such as runtime.powerState.
+
+// Valid returns true if e is a defined HostSystemConnectionState value.
+func (e HostSystemConnectionState) Valid() bool {
+	switch e {
+	case HostSystemConnectionStateConnected,
+		HostSystemConnectionStateNotResponding,
+		HostSystemConnectionStateDisconnected:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined HostSystemPowerState value.
+func (e HostSystemPowerState) Valid() bool {
+	switch e {
+	case HostSystemPowerStatePoweredOn,
+		HostSystemPowerStatePoweredOff,
+		HostSystemPowerStateStandBy,
+		HostSystemPowerStateUnknown:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined ManagedEntityStatus value.
+func (e ManagedEntityStatus) Valid() bool {
+	switch e {
+	case ManagedEntityStatusGray,
+		ManagedEntityStatusGreen,
+		ManagedEntityStatusYellow,
+		ManagedEntityStatusRed:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined TaskInfoState value.
+func (e TaskInfoState) Valid() bool {
+	switch e {
+	case TaskInfoStateQueued,
+		TaskInfoStateRunning,
+		TaskInfoStateSuccess,
+		TaskInfoStateError:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined VirtualMachineConnectionState value.
+func (e VirtualMachineConnectionState) Valid() bool {
+	switch e {
+	case VirtualMachineConnectionStateConnected,
+		VirtualMachineConnectionStateDisconnected,
+		VirtualMachineConnectionStateOrphaned,
+		VirtualMachineConnectionStateInaccessible,
+		VirtualMachineConnectionStateInvalid:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined VirtualMachineGuestState value.
+func (e VirtualMachineGuestState) Valid() bool {
+	switch e {
+	case VirtualMachineGuestStateRunning,
+		VirtualMachineGuestStateShuttingDown,
+		VirtualMachineGuestStateResetting,
+		VirtualMachineGuestStateStandby,
+		VirtualMachineGuestStateNotRunning,
+		VirtualMachineGuestStateUnknown:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined VirtualMachinePowerState value.
+func (e VirtualMachinePowerState) Valid() bool {
+	switch e {
+	case VirtualMachinePowerStatePoweredOff,
+		VirtualMachinePowerStatePoweredOn,
+		VirtualMachinePowerStateSuspended:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined VirtualMachineToolsRunningStatus value.
+func (e VirtualMachineToolsRunningStatus) Valid() bool {
+	switch e {
+	case VirtualMachineToolsRunningStatusGuestToolsNotRunning,
+		VirtualMachineToolsRunningStatusGuestToolsRunning,
+		VirtualMachineToolsRunningStatusGuestToolsExecutingScripts:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined VirtualMachineToolsStatus value.
+func (e VirtualMachineToolsStatus) Valid() bool {
+	switch e {
+	case VirtualMachineToolsStatusToolsNotInstalled,
+		VirtualMachineToolsStatusToolsNotRunning,
+		VirtualMachineToolsStatusToolsOld,
+		VirtualMachineToolsStatusToolsOk:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if e is a defined VirtualMachineToolsVersionStatus value.
+func (e VirtualMachineToolsVersionStatus) Valid() bool {
+	switch e {
+	case VirtualMachineToolsVersionStatusGuestToolsNotInstalled,
+		VirtualMachineToolsVersionStatusGuestToolsNeedUpgrade,
+		VirtualMachineToolsVersionStatusGuestToolsCurrent,
+		VirtualMachineToolsVersionStatusGuestToolsUnmanaged,
+		VirtualMachineToolsVersionStatusGuestToolsTooOld,
+		VirtualMachineToolsVersionStatusGuestToolsSupportedOld,
+		VirtualMachineToolsVersionStatusGuestToolsSupportedNew,
+		VirtualMachineToolsVersionStatusGuestToolsTooNew,
+		VirtualMachineToolsVersionStatusGuestToolsBlacklisted:
+		return true
+	}
+	return false
+}
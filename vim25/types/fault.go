@@ -16,6 +16,8 @@ limitations under the License.
 
 package types
 
+// HasFault is implemented by errors that carry a method fault,
+// including task.Error and the SOAP and vim fault errors returned by the soap package.
 type HasFault interface {
 	Fault() BaseMethodFault
 }
@@ -30,3 +32,25 @@ func IsFileNotFound(err error) bool {
 
 	return false
 }
+
+func IsDuplicateName(err error) bool {
+	if f, ok := err.(HasFault); ok {
+		switch f.Fault().(type) {
+		case *DuplicateName:
+			return true
+		}
+	}
+
+	return false
+}
+
+func IsInvalidPowerState(err error) bool {
+	if f, ok := err.(HasFault); ok {
+		switch f.Fault().(type) {
+		case *InvalidPowerState:
+			return true
+		}
+	}
+
+	return false
+}
@@ -51,10 +51,12 @@ func (r ManagedObjectReference) String() string {
 	return strings.Join([]string{r.Type, r.Value}, ":")
 }
 
+// FromString parses a reference in the "Type:Value" format produced by String.
+// False is returned if o is not in that format or the Type token is not a valid type name.
 func (r *ManagedObjectReference) FromString(o string) bool {
 	s := strings.SplitN(o, ":", 2)
 
-	if len(s) < 2 {
+	if len(s) < 2 || s[1] == "" || !isTypeName(s[0]) {
 		return false
 	}
 
@@ -64,6 +66,24 @@ func (r *ManagedObjectReference) FromString(o string) bool {
 	return true
 }
 
+// isTypeName returns true if s is a valid managed object type name, such as "VirtualMachine"
+func isTypeName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, c := range s {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9' && i != 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
 // Encode ManagedObjectReference for use with URL and File paths
 func (r ManagedObjectReference) Encode() string {
 	return strings.Join([]string{r.Type, url.QueryEscape(r.Value)}, "-")
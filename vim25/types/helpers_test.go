@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2021 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "testing"
+
+func TestManagedObjectReferenceFromString(t *testing.T) {
+	tests := []struct {
+		in  string
+		ok  bool
+		ref ManagedObjectReference
+	}{
+		{"VirtualMachine:vm-42", true, ManagedObjectReference{Type: "VirtualMachine", Value: "vm-42"}},
+		{"Folder:group-d1", true, ManagedObjectReference{Type: "Folder", Value: "group-d1"}},
+		{"HostSystem:host-1:extra", true, ManagedObjectReference{Type: "HostSystem", Value: "host-1:extra"}},
+		{"vm-42", false, ManagedObjectReference{}},
+		{":vm-42", false, ManagedObjectReference{}},
+		{"VirtualMachine:", false, ManagedObjectReference{}},
+		{"/dc1/vm/foo:bar", false, ManagedObjectReference{}},
+		{"1Folder:group-d1", false, ManagedObjectReference{}},
+	}
+
+	for _, test := range tests {
+		var ref ManagedObjectReference
+		ok := ref.FromString(test.in)
+		if ok != test.ok {
+			t.Errorf("%s: ok=%t", test.in, ok)
+		}
+		if ref != test.ref {
+			t.Errorf("%s: ref=%s", test.in, ref)
+		}
+		if ok && ref.String() != test.in {
+			t.Errorf("%s: String()=%s", test.in, ref)
+		}
+	}
+}
+
+func TestEnumValid(t *testing.T) {
+	tests := []struct {
+		in interface{ Valid() bool }
+		ok bool
+	}{
+		{VirtualMachinePowerStatePoweredOn, true},
+		{VirtualMachinePowerState("poweredon"), false},
+		{VirtualMachinePowerState(""), false},
+		{HostSystemConnectionStateNotResponding, true},
+		{HostSystemConnectionState("notresponding"), false},
+		{ManagedEntityStatusGreen, true},
+		{ManagedEntityStatus("blue"), false},
+		{TaskInfoStateSuccess, true},
+		{TaskInfoState("done"), false},
+	}
+
+	for _, test := range tests {
+		if ok := test.in.Valid(); ok != test.ok {
+			t.Errorf("%#v: Valid()=%t", test.in, ok)
+		}
+	}
+}